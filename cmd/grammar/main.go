@@ -0,0 +1,291 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"text/template"
+
+	ggen "github.com/PlayerR9/lib_units/generator"
+)
+
+var (
+	t       *template.Template
+	t_table *template.Template
+	Logger  *log.Logger
+)
+
+func init() {
+	t = template.Must(template.New("").Parse(templ))
+	t_table = template.Must(template.New("").Parse(templ_table))
+	Logger = ggen.InitLogger("grammar")
+}
+
+var (
+	// GrammarFlag is the flag that specifies the location of the grammar file.
+	GrammarFlag *string
+
+	// TypeNameFlag is the flag that specifies the name of the token type.
+	TypeNameFlag *string
+
+	// PrefixFlag is the flag that specifies the prefix of the constants.
+	PrefixFlag *string
+
+	// ModeFlag is the flag that specifies what to generate. Either "tokens" or "table".
+	ModeFlag *string
+)
+
+func init() {
+	ggen.SetOutputFlag("", false)
+
+	GrammarFlag = flag.String("g", "", "The location of the grammar file. It must be set.")
+	TypeNameFlag = flag.String("type", "TokenType", "The name of the token type.")
+	PrefixFlag = flag.String("prefix", "Tk", "The prefix of the constants of the token type.")
+	ModeFlag = flag.String("mode", "tokens", "What to generate. Either \"tokens\", for the token type, "+
+		"or \"table\", for the pre-computed decision table. The table uses the constants of the token type.")
+}
+
+// GenData is the data used in the template.
+type GenData struct {
+	// PackageName is the name of the package.
+	PackageName string
+
+	// TypeName is the name of the token type.
+	TypeName string
+
+	// Terminals are the terminals of the grammar. Accept symbols come first.
+	Terminals []Symbol
+
+	// NonTerminals are the non-terminals of the grammar.
+	NonTerminals []Symbol
+
+	// Rules are the literals of the rules of the decision table.
+	Rules []string
+
+	// Helpers is the literal of the helpers of the decision table.
+	Helpers string
+
+	// States are the states of the decision table.
+	States []StateData
+}
+
+// SetPackageName implements the ggen.Generater interface.
+func (g GenData) SetPackageName(pkg_name string) ggen.Generater {
+	g.PackageName = pkg_name
+	return g
+}
+
+func main() {
+	err := ggen.ParseFlags()
+	if err != nil {
+		Logger.Fatalf("Could not parse flags: %s", err.Error())
+	}
+
+	if *GrammarFlag == "" {
+		Logger.Fatalf("Flag -g must be set")
+	}
+
+	err = ggen.IsValidName(*TypeNameFlag, nil, ggen.Exported)
+	if err != nil {
+		Logger.Fatalf("Invalid type name: %s", err.Error())
+	}
+
+	data, err := os.ReadFile(*GrammarFlag)
+	if err != nil {
+		Logger.Fatalf("Could not read grammar: %s", err.Error())
+	}
+
+	var output_loc string
+
+	switch *ModeFlag {
+	case "tokens":
+		output_loc, err = ggen.FixOutputLoc(*TypeNameFlag, "_generated.go")
+		if err != nil {
+			Logger.Fatalf("Could not fix output location: %s", err.Error())
+		}
+
+		err = ggen.Generate(output_loc, GenData{TypeName: *TypeNameFlag}, t,
+			func(g *GenData) error {
+				terminals, nonterminals, err := make_symbols(string(data), *PrefixFlag)
+				if err != nil {
+					return fmt.Errorf("in %q: %w", *GrammarFlag, err)
+				}
+
+				g.Terminals = terminals
+				g.NonTerminals = nonterminals
+
+				return nil
+			},
+		)
+	case "table":
+		output_loc, err = ggen.FixOutputLoc(*TypeNameFlag, "_table.go")
+		if err != nil {
+			Logger.Fatalf("Could not fix output location: %s", err.Error())
+		}
+
+		err = ggen.Generate(output_loc, GenData{TypeName: *TypeNameFlag}, t_table,
+			func(g *GenData) error {
+				rules, helpers, states, err := make_table(string(data), *PrefixFlag, *TypeNameFlag)
+				if err != nil {
+					return fmt.Errorf("in %q: %w", *GrammarFlag, err)
+				}
+
+				g.Rules = rules
+				g.Helpers = helpers
+				g.States = states
+
+				return nil
+			},
+		)
+	default:
+		Logger.Fatalf("Flag -mode must be either \"tokens\" or \"table\", got %q instead", *ModeFlag)
+	}
+
+	if err != nil {
+		Logger.Fatalf("Could not generate code: %s", err.Error())
+	}
+
+	err = format_file(output_loc)
+	if err != nil {
+		Logger.Fatalf("Could not format code: %s", err.Error())
+	}
+}
+
+// format_file formats the generated file with gofmt.
+//
+// Parameters:
+//   - loc: The location of the file.
+//
+// Returns:
+//   - error: An error if the file could not be read, formatted or written.
+func format_file(loc string) error {
+	data, err := os.ReadFile(loc)
+	if err != nil {
+		return err
+	}
+
+	res, err := format.Source(data)
+	if err != nil {
+		return errors.Join(errors.New("generated code is not valid Go"), err)
+	}
+
+	return os.WriteFile(loc, res, 0644)
+}
+
+const templ = `// Code generated by go_generator. DO NOT EDIT.
+package {{ .PackageName }}
+
+import (
+	utpx "github.com/PlayerR9/go_generator/util/parsing"
+)
+
+// {{ .TypeName }} is the type of the tokens of the grammar.
+type {{ .TypeName }} int
+
+const (
+	// Lexer tokens
+{{ range $i, $s := .Terminals }}
+	// {{ $s.Const }} is the {{ printf "%q" $s.Name }} token.
+	{{ $s.Const }}{{ if eq $i 0 }} {{ $.TypeName }} = iota{{ end }}
+{{ end }}
+	// Parsing tokens
+{{ range .NonTerminals }}
+	// {{ .Const }} is the {{ printf "%q" .Name }} {{ if .IsHelper }}helper {{ end }}token.
+	{{ .Const }}
+{{ end -}}
+)
+
+// IsAcceptSymbol implements the parsing.TokenTyper interface.
+func (t {{ .TypeName }}) IsAcceptSymbol() bool {
+	switch t {
+	case {{ range $i, $s := .Terminals }}{{ if $s.IsAccept }}{{ if $i }}, {{ end }}{{ $s.Const }}{{ end }}{{ end }}:
+		return true
+	}
+
+	return false
+}
+
+// IsTerminal implements the parsing.TokenTyper interface.
+func (t {{ .TypeName }}) IsTerminal() bool {
+	switch t {
+	case {{ range $i, $s := .Terminals }}{{ if $i }}, {{ end }}{{ $s.Const }}{{ end }}:
+		return true
+	}
+
+	return false
+}
+
+// String implements the parsing.TokenTyper interface.
+func (t {{ .TypeName }}) String() string {
+	return [...]string{
+	{{- range .Terminals }}
+		{{ printf "%q" .Desc }},
+	{{- end }}
+{{ range .NonTerminals }}
+		{{ printf "%q" .Desc }},
+	{{- end }}
+	}[t]
+}
+
+// GoString implements the parsing.TokenTyper interface.
+func (t {{ .TypeName }}) GoString() string {
+	return [...]string{
+	{{- range .Terminals }}
+		{{ printf "%q" .Const }},
+	{{- end }}
+{{ range .NonTerminals }}
+		{{ printf "%q" .Const }},
+	{{- end }}
+	}[t]
+}
+
+// SttFunc is the string to type function of the grammar; it maps the names of the symbols
+// of the grammar to their type. Never nil.
+var SttFunc utpx.StringToTypeFunc[{{ .TypeName }}] = func(field string) ({{ .TypeName }}, bool) {
+	switch field {
+	{{- range .Terminals }}
+	case {{ printf "%q" .Name }}:
+		return {{ .Const }}, true
+	{{- end }}
+	{{- range .NonTerminals }}
+	case {{ printf "%q" .Name }}:
+		return {{ .Const }}, true
+	{{- end }}
+	default:
+		return 0, false
+	}
+}
+`
+
+const templ_table = `// Code generated by go_generator. DO NOT EDIT.
+package {{ .PackageName }}
+
+import (
+	utpx "github.com/PlayerR9/go_generator/util/parsing"
+)
+
+// StaticTable is the decision table of the grammar; computed when this file was generated.
+// Use utpx.NewStaticDecisionTable to load it.
+var StaticTable *utpx.StaticTable[{{ .TypeName }}] = &utpx.StaticTable[{{ .TypeName }}]{
+	Rules: []utpx.StaticRule[{{ .TypeName }}]{
+	{{- range .Rules }}
+		{{ . }},
+	{{- end }}
+	},
+	Helpers: {{ .Helpers }},
+	States: []utpx.StaticState[{{ .TypeName }}]{
+	{{- range $i, $s := .States }}
+		{ // State {{ $i }}
+			Gotos:   {{ $s.Gotos }},
+			Actions: {{ $s.Actions }},
+			{{- if $s.End }}
+			End:     {{ $s.End }},
+			{{- end }}
+		},
+	{{- end }}
+	},
+}
+`
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"go/format"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestTokensTemplate(t *testing.T) {
+	const grammar string = `
+Source = Expr EOF .
+Expr = num { plus_sign num } .
+`
+
+	terminals, nonterminals, err := make_symbols(grammar, "Tk")
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	code, err := generate_tokens(GenData{
+		PackageName:  "parsing",
+		TypeName:     "TokenType",
+		Terminals:    terminals,
+		NonTerminals: nonterminals,
+	})
+	if err != nil {
+		t.Fatalf("expected valid Go, got %s", err.Error())
+	}
+
+	// The constants, in the order of their values.
+	consts := regexp.MustCompile(`(?m)^\t(Tk\w+)( TokenType = iota)?$`).FindAllStringSubmatch(code, -1)
+
+	// The results of GoString and of SttFunc.
+	_, go_strings, _ := strings.Cut(code, "func (t TokenType) GoString() string {")
+	go_names := regexp.MustCompile(`"(Tk\w+)",`).FindAllStringSubmatch(go_strings, -1)
+	cases := regexp.MustCompile(`case "(\w+)":\n\t\treturn (Tk\w+), true`).FindAllStringSubmatch(code, -1)
+
+	symbols := append(slices.Clone(terminals), nonterminals...)
+
+	if len(consts) != len(symbols) || len(go_names) != len(symbols) || len(cases) != len(symbols) {
+		t.Fatalf("expected %d constants, names and cases, got %d, %d and %d", len(symbols), len(consts), len(go_names), len(cases))
+	}
+
+	// Every name of the grammar gives the type of its symbol.
+	for i, symbol := range symbols {
+		if cases[i][1] != symbol.Name || cases[i][2] != symbol.Const {
+			t.Errorf("expected %q to give %s, got %q and %s", symbol.Name, symbol.Const, cases[i][1], cases[i][2])
+		}
+
+		idx := slices.IndexFunc(consts, func(m []string) bool { return m[1] == cases[i][2] })
+		if idx == -1 || go_names[idx][1] != symbol.Const {
+			t.Errorf("expected the type of %q to be %s", symbol.Name, symbol.Const)
+		}
+	}
+
+	if !strings.Contains(code, "default:\n\t\treturn 0, false") {
+		t.Errorf("expected unknown names to give no type")
+	}
+}
+
+// generate_tokens is a helper function that generates the token type of the given data
+// and formats it.
+func generate_tokens(data GenData) (string, error) {
+	var builder strings.Builder
+
+	err := t.Execute(&builder, data)
+	if err != nil {
+		return "", err
+	}
+
+	res, err := format.Source([]byte(builder.String()))
+	if err != nil {
+		return "", err
+	}
+
+	return string(res), nil
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	utpx "github.com/PlayerR9/go_generator/util/parsing"
+)
+
+// Symbol is a symbol of the grammar as it appears in the generated code.
+type Symbol struct {
+	// Name is the name of the symbol in the grammar.
+	Name string
+
+	// Const is the name of the constant of the symbol.
+	Const string
+
+	// Desc is the description returned by the String() method.
+	Desc string
+
+	// IsAccept is true if the symbol is an accept symbol.
+	IsAccept bool
+
+	// IsHelper is true if the symbol was introduced by a repetition.
+	IsHelper bool
+}
+
+// AcceptName is the name of the accept symbol of a grammar.
+const AcceptName string = "EOF"
+
+// is_terminal is a helper function that infers from the casing of a name whether
+// it is a terminal. Names that start with a lowercase letter (e.g., op_curly) and the
+// accept symbol (EOF) are terminals; any other name (e.g., Source) is a non-terminal.
+//
+// Parameters:
+//   - name: The name of the symbol.
+//
+// Returns:
+//   - bool: True if the symbol is a terminal, false otherwise.
+//   - bool: True if the symbol is the accept symbol, false otherwise.
+func is_terminal(name string) (bool, bool) {
+	if name == AcceptName {
+		return true, true
+	}
+
+	c, _ := utf8.DecodeRuneInString(name)
+
+	return unicode.IsLower(c), false
+}
+
+// const_name is a helper function that converts the name of a symbol into the name of
+// its constant. (e.g., op_curly -> TkOpCurly)
+//
+// Parameters:
+//   - prefix: The prefix of the constant.
+//   - name: The name of the symbol.
+//
+// Returns:
+//   - string: The name of the constant.
+func const_name(prefix, name string) string {
+	var builder strings.Builder
+
+	builder.WriteString(prefix)
+
+	for _, field := range strings.Split(name, "_") {
+		if field == "" {
+			continue
+		}
+
+		chars := []rune(field)
+		chars[0] = unicode.ToUpper(chars[0])
+
+		builder.WriteString(string(chars))
+	}
+
+	return builder.String()
+}
+
+// make_symbols creates the symbols of a grammar.
+//
+// Terminals come first, starting with the accept symbols, followed by the
+// non-terminals. Within each group, the symbols are in the order they first appear
+// in the grammar.
+//
+// Parameters:
+//   - grammar: The grammar.
+//   - prefix: The prefix of the constants.
+//
+// Returns:
+//   - []Symbol: The terminals.
+//   - []Symbol: The non-terminals.
+//   - error: An error if the grammar is invalid or if it has no accept symbol.
+func make_symbols(grammar, prefix string) ([]Symbol, []Symbol, error) {
+	names, helpers, err := utpx.ParseGrammarNames(grammar)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	is_helper := make(map[string]bool, len(helpers))
+
+	for _, helper := range helpers {
+		is_helper[helper] = true
+	}
+
+	var accepts, terminals, nonterminals []Symbol
+	seen := make(map[string]string)
+
+	for _, name := range names {
+		sym := Symbol{
+			Name:     name,
+			Const:    const_name(prefix, name),
+			IsHelper: is_helper[name],
+		}
+
+		prev, ok := seen[sym.Const]
+		if ok {
+			return nil, nil, fmt.Errorf("symbols %q and %q have the same constant %q", prev, name, sym.Const)
+		}
+
+		seen[sym.Const] = name
+
+		ok, sym.IsAccept = is_terminal(name)
+		if !ok {
+			sym.Desc = name
+			nonterminals = append(nonterminals, sym)
+		} else if sym.IsAccept {
+			sym.Desc = name
+			accepts = append(accepts, sym)
+		} else {
+			sym.Desc = strings.ReplaceAll(name, "_", " ")
+			terminals = append(terminals, sym)
+		}
+	}
+
+	if len(accepts) == 0 {
+		return nil, nil, fmt.Errorf("grammar has no accept symbol; end the start rule with %s", AcceptName)
+	}
+
+	return append(accepts, terminals...), nonterminals, nil
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestMakeSymbols(t *testing.T) {
+	const grammar string = `
+Source = Expr EOF .
+Expr = num { plus_sign num } .
+`
+
+	terminals, nonterminals, err := make_symbols(grammar, "Tk")
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	expecteds := []Symbol{
+		{Name: "EOF", Const: "TkEOF", Desc: "EOF", IsAccept: true},
+		{Name: "num", Const: "TkNum", Desc: "num"},
+		{Name: "plus_sign", Const: "TkPlusSign", Desc: "plus sign"},
+	}
+
+	if len(terminals) != len(expecteds) {
+		t.Fatalf("expected %v, got %v", expecteds, terminals)
+	}
+
+	for i, expected := range expecteds {
+		if terminals[i] != expected {
+			t.Errorf("expected %v, got %v", expected, terminals[i])
+		}
+	}
+
+	expecteds = []Symbol{
+		{Name: "Source", Const: "TkSource", Desc: "Source"},
+		{Name: "Expr", Const: "TkExpr", Desc: "Expr"},
+		{Name: "Expr1", Const: "TkExpr1", Desc: "Expr1", IsHelper: true},
+	}
+
+	if len(nonterminals) != len(expecteds) {
+		t.Fatalf("expected %v, got %v", expecteds, nonterminals)
+	}
+
+	for i, expected := range expecteds {
+		if nonterminals[i] != expected {
+			t.Errorf("expected %v, got %v", expected, nonterminals[i])
+		}
+	}
+}
+
+func TestMakeSymbolsNoAccept(t *testing.T) {
+	_, _, err := make_symbols("Source = Expr eof .\nExpr = num .", "Tk")
+	if err == nil {
+		t.Errorf("expected error, got nil")
+	}
+}
+
+func TestMakeSymbolsUppercaseNonTerminal(t *testing.T) {
+	terminals, nonterminals, err := make_symbols("Source = A EOF .\nA = num .", "Tk")
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	if len(terminals) != 2 || terminals[0].Name != "EOF" || !terminals[0].IsAccept || terminals[1].Name != "num" {
+		t.Errorf("expected the terminals EOF and num, got %v", terminals)
+	}
+
+	if len(nonterminals) != 2 || nonterminals[1].Name != "A" {
+		t.Errorf("expected A to be a non-terminal, got %v", nonterminals)
+	}
+}
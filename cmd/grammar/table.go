@@ -0,0 +1,181 @@
+package main
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	utpx "github.com/PlayerR9/go_generator/util/parsing"
+)
+
+// symbol is the token type used to build the decision table at generation time. Its
+// value is the index of the symbol in grammar_symbols; which is also the value of the
+// generated constant.
+type symbol int
+
+var (
+	// grammar_symbols are the symbols of the grammar being generated; terminals first.
+	grammar_symbols []Symbol
+)
+
+// IsAcceptSymbol implements the parsing.TokenTyper interface.
+func (s symbol) IsAcceptSymbol() bool {
+	return grammar_symbols[s].IsAccept
+}
+
+// IsTerminal implements the parsing.TokenTyper interface.
+func (s symbol) IsTerminal() bool {
+	ok, _ := is_terminal(grammar_symbols[s].Name)
+	return ok
+}
+
+// String implements the parsing.TokenTyper interface.
+func (s symbol) String() string {
+	return grammar_symbols[s].Desc
+}
+
+// GoString implements the parsing.TokenTyper interface.
+func (s symbol) GoString() string {
+	return grammar_symbols[s].Const
+}
+
+// StateData is a state of the decision table as it appears in the generated code.
+type StateData struct {
+	// Gotos is the literal of the transitions.
+	Gotos string
+
+	// Actions is the literal of the actions.
+	Actions string
+
+	// End is the literal of the action at the end of the input. Empty if there is none.
+	End string
+}
+
+// make_table builds the decision table of a grammar and converts it into literals.
+//
+// Parameters:
+//   - grammar: The grammar.
+//   - prefix: The prefix of the constants.
+//   - type_name: The name of the token type.
+//
+// Returns:
+//   - []string: The literals of the rules.
+//   - string: The literal of the helpers.
+//   - []StateData: The states.
+//   - error: An error if the grammar is invalid or has conflicts.
+func make_table(grammar, prefix, type_name string) ([]string, string, []StateData, error) {
+	terminals, nonterminals, err := make_symbols(grammar, prefix)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	grammar_symbols = append(terminals, nonterminals...)
+
+	f := func(field string) (symbol, bool) {
+		idx := slices.IndexFunc(grammar_symbols, func(s Symbol) bool {
+			return s.Name == field
+		})
+
+		return symbol(idx), idx != -1
+	}
+
+	dt, err := utpx.NewDecisionTable(grammar, f)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	table := dt.Static()
+
+	rules := make([]string, 0, len(table.Rules))
+
+	for _, rule := range table.Rules {
+		rules = append(rules, "{Lhs: "+rule.Lhs.GoString()+", Rhss: "+symbols_literal(type_name, rule.Rhss)+"}")
+	}
+
+	states := make([]StateData, 0, len(table.States))
+
+	for _, state := range table.States {
+		var data StateData
+
+		var values []string
+
+		for _, key := range sorted_keys(state.Gotos) {
+			values = append(values, key.GoString()+": "+strconv.Itoa(state.Gotos[key]))
+		}
+
+		data.Gotos = "map[" + type_name + "]int{" + strings.Join(values, ", ") + "}"
+
+		values = values[:0]
+
+		for _, key := range sorted_keys(state.Actions) {
+			values = append(values, key.GoString()+": "+action_literal(state.Actions[key]))
+		}
+
+		data.Actions = "map[" + type_name + "]utpx.StaticAction{" + strings.Join(values, ", ") + "}"
+
+		if state.End != nil {
+			data.End = "&utpx.StaticAction" + action_literal(*state.End)
+		}
+
+		states = append(states, data)
+	}
+
+	return rules, symbols_literal(type_name, table.Helpers), states, nil
+}
+
+// symbols_literal is a helper function that returns the literal of a slice of symbols.
+//
+// Parameters:
+//   - type_name: The name of the token type.
+//   - symbols: The symbols.
+//
+// Returns:
+//   - string: The literal.
+func symbols_literal(type_name string, symbols []symbol) string {
+	values := make([]string, 0, len(symbols))
+
+	for _, s := range symbols {
+		values = append(values, s.GoString())
+	}
+
+	return "[]" + type_name + "{" + strings.Join(values, ", ") + "}"
+}
+
+// action_literal is a helper function that returns the literal of a static action
+// without its type.
+//
+// Parameters:
+//   - act: The action.
+//
+// Returns:
+//   - string: The literal.
+func action_literal(act utpx.StaticAction) string {
+	switch act.Kind {
+	case utpx.StaticShift:
+		return "{Kind: utpx.StaticShift}"
+	case utpx.StaticReduce:
+		return "{Kind: utpx.StaticReduce, Rule: " + strconv.Itoa(act.Rule) + "}"
+	default:
+		return "{Kind: utpx.StaticAccept, Rule: " + strconv.Itoa(act.Rule) + "}"
+	}
+}
+
+// sorted_keys is a helper function that returns the keys of a map in ascending order so
+// that the generated code is deterministic.
+//
+// Parameters:
+//   - m: The map.
+//
+// Returns:
+//   - []symbol: The sorted keys.
+func sorted_keys[V any](m map[symbol]V) []symbol {
+	keys := make([]symbol, 0, len(m))
+
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	return keys
+}
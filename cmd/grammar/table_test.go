@@ -0,0 +1,50 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestMakeTable(t *testing.T) {
+	const grammar string = `
+Source = Expr EOF .
+Expr = num { plus num } .
+`
+
+	rules, helpers, states, err := make_table(grammar, "Tk", "TokenType")
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	expected := "{Lhs: TkExpr, Rhss: []TokenType{TkNum, TkExpr1}}"
+
+	if len(rules) != 5 || rules[2] != expected {
+		t.Errorf("expected %q as third rule, got %v", expected, rules)
+	}
+
+	if helpers != "[]TokenType{TkExpr1}" {
+		t.Errorf("unexpected helpers %q", helpers)
+	}
+
+	if len(states) == 0 {
+		t.Fatalf("expected states, got none")
+	}
+
+	expected = "map[TokenType]utpx.StaticAction{TkNum: {Kind: utpx.StaticShift}}"
+
+	if states[0].Actions != expected {
+		t.Errorf("expected %q, got %q", expected, states[0].Actions)
+	}
+}
+
+func TestMakeTableConflict(t *testing.T) {
+	const grammar string = `
+Source = Expr EOF .
+Expr = Expr plus Expr .
+Expr = num .
+`
+
+	_, _, _, err := make_table(grammar, "Tk", "TokenType")
+	if err == nil {
+		t.Errorf("expected error, got nil")
+	}
+}
@@ -6,3 +6,5 @@ require (
 	github.com/PlayerR9/MyGoLib v0.4.8
 	github.com/PlayerR9/lib_units v0.1.1
 )
+
+require golang.org/x/exp v0.0.0-20240719175910-8a7402abbf56 // indirect
@@ -0,0 +1,1148 @@
+package pkg
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	fstr "github.com/PlayerR9/MyGoLib/Formatting/Strings"
+	prx "github.com/PlayerR9/go_generator/pkg/parsing"
+	utpx "github.com/PlayerR9/go_generator/util/parsing"
+	uc "github.com/PlayerR9/lib_units/common"
+	us "github.com/PlayerR9/lib_units/slices"
+)
+
+// NodeType is the type of a token.
+type NodeType int
+
+const (
+	// SourceNode is the source node.
+	SourceNode NodeType = iota
+
+	// VariableNode is the variable node.
+	VariableNode
+
+	// TextNode is the text node.
+	TextNode
+
+	// IfNode is the if node. Its children are the branches of the block; in order.
+	IfNode
+
+	// BranchNode is a branch of an if, a range, a define or a block node. Its data is the
+	// pipeline of the condition (or of the collection, or of the dot of a block); empty for
+	// the else branch and the name of the sub-template for a define. Its children are the
+	// nodes of the body.
+	BranchNode
+
+	// RangeNode is the range node. Its data is the pipeline of the collection and its
+	// children are the branch of the body followed by the else branch, if any.
+	RangeNode
+
+	// AssignNode is the assignment node. It declares its only variable with the value of
+	// its pipeline.
+	AssignNode
+
+	// PipelineNode is the pipeline node. Its children are the stages of the pipeline; the
+	// value of each stage is given to the next one as its last argument.
+	PipelineNode
+
+	// CallNode is the function call node. Its data is the name of the function and its
+	// children are the arguments.
+	CallNode
+
+	// FieldNode is the field node. Its data is the field path. (e.g., ".A.B" or "$v.Name")
+	FieldNode
+
+	// LiteralNode is the literal node. Its data is the literal as written in the template.
+	LiteralNode
+
+	// CommentNode is the comment node. Its data is the comment as written in the template.
+	// (e.g., "/* a note */") It produces no output.
+	CommentNode
+
+	// DefineNode is the define node. Its data is the name of the sub-template and its only
+	// child is the branch of the body. It produces no output.
+	DefineNode
+
+	// TemplateNode is the template node. Its data is the name of the sub-template it
+	// executes; with the value of its pipeline, if any, as the dot.
+	TemplateNode
+
+	// BlockNode is the block node. Its data is the name of the sub-template it defines and
+	// executes with the value of its pipeline as the dot. Its only child is the branch of
+	// the body; unless a define node of the same name overrides it.
+	BlockNode
+)
+
+// String implements the common.Enumer interface.
+func (t NodeType) String() string {
+	return [...]string{
+		"Source",
+		"Variable",
+		"Text",
+		"If",
+		"Branch",
+		"Range",
+		"Assign",
+		"Pipeline",
+		"Call",
+		"Field",
+		"Literal",
+		"Comment",
+		"Define",
+		"Template",
+		"Block",
+	}[t]
+}
+
+// Node is the AST node.
+type Node struct {
+	// Parent is the parent node.
+	Parent *Node
+
+	// Kind is the type of the node.
+	Kind NodeType
+
+	// Data is the data of the node.
+	Data string
+
+	// Vars are the variables declared by a range or an assignment node; the index (or key)
+	// first.
+	Vars []string
+
+	// Pipe is the pipeline of a variable, an assignment, a range, a branch, a template or a
+	// block node. Their data is the text of the pipeline.
+	Pipe *Node
+
+	// Pos is the position of a field, a define, a template or a block node in the template.
+	Pos utpx.Position
+
+	// Children is the list of children nodes.
+	Children []*Node
+}
+
+// String implements the tree.Noder interface.
+//
+// Format:
+//
+//	Node[Kind (Data)]
+func (n *Node) String() string {
+	var builder strings.Builder
+
+	builder.WriteString("Node[")
+	builder.WriteString(n.Kind.String())
+
+	if n.Data != "" {
+		builder.WriteString(" (")
+
+		if len(n.Vars) > 0 {
+			builder.WriteString(strings.Join(n.Vars, ", "))
+			builder.WriteString(" := ")
+		}
+
+		builder.WriteString(n.Data)
+		builder.WriteString(")")
+	}
+
+	builder.WriteString("]")
+
+	return builder.String()
+}
+
+// IsLeaf implements the tree.Noder interface.
+func (n *Node) IsLeaf() bool {
+	return len(n.Children) == 0
+}
+
+// Iterator implements the tree.Noder interface.
+//
+// Never returns nil
+func (n *Node) Iterator() uc.Iterater[fstr.Noder] {
+	var children []fstr.Noder
+
+	if len(n.Children) != 0 {
+		children = make([]fstr.Noder, 0, len(n.Children))
+		for _, c := range n.Children {
+			children = append(children, c)
+		}
+	}
+
+	return uc.NewSimpleIterator(children)
+}
+
+// NewNode creates a new node.
+//
+// Parameters:
+//   - kind: The type of the node.
+//   - data: The data of the node.
+//
+// Returns:
+//   - *Node: The node. Never returns nil.
+func NewNode(kind NodeType, data string) *Node {
+	return &Node{
+		Kind:     kind,
+		Data:     data,
+		Children: make([]*Node, 0),
+	}
+}
+
+// SetChildren sets the children of the node. It skips nil children.
+//
+// Parameters:
+//   - children: The children of the node.
+func (n *Node) SetChildren(children []*Node) {
+	children = us.FilterNilValues(children)
+	if len(children) == 0 {
+		return
+	}
+
+	for i := 0; i < len(children); i++ {
+		children[i].Parent = n
+	}
+
+	n.Children = children
+}
+
+// ToAST converts the token tree to an AST.
+//
+// Parameters:
+//   - root: The root token of the tree.
+//   - funcs: The functions the template can call. Their signatures must be valid.
+//
+// Returns:
+//   - *Node: The AST. Never returns nil.
+//   - error: An error if the tree is invalid. An *utpx.ErrSyntax if it calls an undefined
+//     function or a function with the wrong number of arguments.
+func ToAST(root *utpx.Token[prx.TokenType], funcs FuncMap) (*Node, error) {
+	return to_ast(root, funcs, prx.DefaultOpen, prx.DefaultClose)
+}
+
+// to_ast is a helper function that converts the token tree to an AST; the given
+// delimiters are those of the template and are only used in error messages.
+//
+// Parameters:
+//   - root: The root token of the tree.
+//   - funcs: The functions the template can call. Their signatures must be valid.
+//   - open: The delimiter that opens an action.
+//   - close: The delimiter that closes an action.
+//
+// Returns:
+//   - *Node: The AST. Never returns nil.
+//   - error: An error if the tree is invalid.
+func to_ast(root *utpx.Token[prx.TokenType], funcs FuncMap, open, close string) (*Node, error) {
+	if root == nil {
+		return nil, uc.NewErrNilParameter("root")
+	}
+
+	if root.Type != prx.TkSource {
+		return nil, fmt.Errorf("expected %q to be a Source node, got %q instead", root.String(), prx.TkSource.String())
+	}
+
+	// Flatten the helpers so that every element is a direct child of the source.
+	prx.DecisionTable.Flatten(root)
+
+	children, ok := root.Data.([]*utpx.Token[prx.TokenType])
+	if !ok {
+		return nil, fmt.Errorf("expected %q to be a non-leaf node, got a leaf node instead", root.String())
+	} else if len(children) < 2 {
+		return nil, fmt.Errorf("expected %q to have at least 2 children, got %d instead", root.String(), len(children))
+	}
+
+	b := new_ast_builder(funcs, open+" end "+close)
+
+	for i, child := range children[:len(children)-1] {
+		b.index = i
+
+		err := b.add(child)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	n, err := b.finish()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		ok := simplify_ast(n)
+		if !ok {
+			break
+		}
+	}
+
+	return n, nil
+}
+
+// add_child is a helper function that adds a child to a node.
+//
+// Parameters:
+//   - parent: The parent node.
+//   - child: The child node.
+func add_child(parent, child *Node) {
+	child.Parent = parent
+	parent.Children = append(parent.Children, child)
+}
+
+// copy_node is a helper function that deep copies a node; including its pipeline. The copy
+// has no parent.
+//
+// Parameters:
+//   - node: The node to copy.
+//
+// Returns:
+//   - *Node: The copy. Never returns nil.
+func copy_node(node *Node) *Node {
+	cp := NewNode(node.Kind, node.Data)
+
+	cp.Vars = slices.Clone(node.Vars)
+	cp.Pos = node.Pos
+
+	if node.Pipe != nil {
+		cp.Pipe = copy_node(node.Pipe)
+	}
+
+	for _, child := range node.Children {
+		add_child(cp, copy_node(child))
+	}
+
+	return cp
+}
+
+// child_of is a helper function that returns the first child of a token with the given type.
+//
+// Parameters:
+//   - root: The token.
+//   - typ: The type of the child.
+//
+// Returns:
+//   - *utpx.Token[prx.TokenType]: The child.
+//   - error: An error if the token has no such child.
+func child_of(root *utpx.Token[prx.TokenType], typ prx.TokenType) (*utpx.Token[prx.TokenType], error) {
+	children, ok := root.Data.([]*utpx.Token[prx.TokenType])
+	if !ok {
+		return nil, fmt.Errorf("expected %q to be a non-leaf node, got a leaf node instead", root.String())
+	}
+
+	for _, child := range children {
+		if child.Type == typ {
+			return child, nil
+		}
+	}
+
+	return nil, fmt.Errorf("expected %q to have a %s", root.String(), typ.String())
+}
+
+// ast_builder is a helper that builds the AST out of the elements of the source; nesting
+// the nodes inside the blocks they belong to.
+type ast_builder struct {
+	// root is the source node.
+	root *Node
+
+	// top is the node the next nodes are added to. Either the root or a branch.
+	top *Node
+
+	// opens are the actions that opened the blocks that are still open; the innermost last.
+	opens []*utpx.Token[prx.TokenType]
+
+	// vars are the variables in scope; the innermost last.
+	vars []string
+
+	// marks are the number of variables in scope when each open block was opened.
+	marks []int
+
+	// bases are the number of variables in scope when each open define or block was
+	// opened; the variables before the innermost base are not in scope in its body.
+	bases []int
+
+	// index is the index of the current element in the source.
+	index int
+
+	// trim_next is true if the previous element is an action that ends with a cl_trim; the
+	// whitespace at the start of the next text is then trimmed.
+	trim_next bool
+
+	// funcs are the functions the template can call.
+	funcs FuncMap
+
+	// end is the action that closes a block. (e.g., "{{ end }}")
+	end string
+}
+
+// new_ast_builder creates a new AST builder.
+//
+// Parameters:
+//   - funcs: The functions the template can call.
+//   - end: The action that closes a block. (e.g., "{{ end }}")
+//
+// Returns:
+//   - *ast_builder: The AST builder. Never returns nil.
+func new_ast_builder(funcs FuncMap, end string) *ast_builder {
+	root := NewNode(SourceNode, "")
+
+	return &ast_builder{
+		root:  root,
+		top:   root,
+		funcs: funcs,
+		end:   end,
+	}
+}
+
+// add adds an element of the source to the AST.
+//
+// Parameters:
+//   - root: The element.
+//
+// Returns:
+//   - error: An error if the element is invalid. An *utpx.ErrSyntax if it breaks the
+//     nesting of the blocks.
+//
+// Assertions:
+//   - The root token must not be nil.
+func (b *ast_builder) add(root *utpx.Token[prx.TokenType]) error {
+	uc.AssertParam("root", root != nil, errors.New("root must not be nil"))
+
+	switch root.Type {
+	case prx.TkElem:
+		children, ok := root.Data.([]*utpx.Token[prx.TokenType])
+		if !ok {
+			return fmt.Errorf("expected %q to be a non-leaf node, got a leaf node instead", root.String())
+		} else if len(children) != 1 {
+			return fmt.Errorf("expected %q to have 1 child, got %d instead", root.String(), len(children))
+		}
+
+		return b.add(children[0])
+	case prx.TkText:
+		data, ok := root.Data.(string)
+		if !ok {
+			return fmt.Errorf("expected %q to be a leaf node, got a non-leaf node instead", root.String())
+		}
+
+		if b.trim_next {
+			data = strings.TrimLeft(data, trim_cutset)
+			b.trim_next = false
+		}
+
+		if data != "" {
+			add_child(b.top, NewNode(TextNode, data))
+		}
+	case prx.TkAction:
+		err := b.add_action(root)
+		if err != nil {
+			return err
+		}
+	default:
+		return utpx.NewErrExpected(&root.Type, nil, prx.TkElem, prx.TkText, prx.TkAction)
+	}
+
+	return nil
+}
+
+// add_action is a helper function that adds an action to the AST.
+//
+// Parameters:
+//   - action: The action.
+//
+// Returns:
+//   - error: An error if the action is invalid.
+func (b *ast_builder) add_action(action *utpx.Token[prx.TokenType]) error {
+	trim_prev, err := is_trimmed(action, prx.TkOpen, prx.TkOpTrim)
+	if err != nil {
+		return err
+	}
+
+	b.trim_next, err = is_trimmed(action, prx.TkClose, prx.TkClTrim)
+	if err != nil {
+		return err
+	}
+
+	if trim_prev {
+		b.trim_last()
+	}
+
+	command, err := child_of(action, prx.TkCommand)
+	if err != nil {
+		return err
+	}
+
+	children, ok := command.Data.([]*utpx.Token[prx.TokenType])
+	if !ok || len(children) != 1 {
+		return fmt.Errorf("expected %q to have 1 child", command.String())
+	}
+
+	cmd := children[0]
+
+	var pipe *Node
+
+	switch cmd.Type {
+	case prx.TkVariable, prx.TkAssign, prx.TkIf, prx.TkElseIf, prx.TkRange, prx.TkBlock:
+		tk, err := child_of(cmd, prx.TkPipeline)
+		if err != nil {
+			return err
+		}
+
+		pipe, err = b.to_pipeline(tk)
+		if err != nil {
+			return err
+		}
+	case prx.TkInclude:
+		tk, err := child_of(cmd, prx.TkPipeline)
+		if err != nil {
+			// The sub-template is executed with a nil dot.
+			break
+		}
+
+		pipe, err = b.to_pipeline(tk)
+		if err != nil {
+			return err
+		}
+	}
+
+	switch cmd.Type {
+	case prx.TkVariable:
+		node := NewNode(VariableNode, pipe.Data)
+		node.Pipe = pipe
+
+		add_child(b.top, node)
+	case prx.TkAssign:
+		tk, err := child_of(cmd, prx.TkVar)
+		if err != nil {
+			return err
+		}
+
+		name, err := b.decl_var(tk)
+		if err != nil {
+			return err
+		}
+
+		node := NewNode(AssignNode, pipe.Data)
+		node.Vars = []string{name}
+		node.Pipe = pipe
+
+		add_child(b.top, node)
+
+		b.vars = append(b.vars, name)
+	case prx.TkIf:
+		node := NewNode(IfNode, "")
+		add_child(b.top, node)
+
+		branch := NewNode(BranchNode, pipe.Data)
+		branch.Pipe = pipe
+		add_child(node, branch)
+
+		b.open(action, branch, nil)
+	case prx.TkRange:
+		vars, err := b.decl_vars(cmd)
+		if err != nil {
+			return err
+		}
+
+		node := NewNode(RangeNode, pipe.Data)
+		node.Vars = vars
+		node.Pipe = pipe
+		add_child(b.top, node)
+
+		branch := NewNode(BranchNode, pipe.Data)
+		add_child(node, branch)
+
+		b.open(action, branch, vars)
+	case prx.TkElseIf, prx.TkElse:
+		if b.top == b.root {
+			return utpx.NewErrSyntax(b.index, action.Start, errors.New("unexpected else outside of a block"))
+		} else if kind := b.top.Parent.Kind; kind == DefineNode || kind == BlockNode {
+			return utpx.NewErrSyntax(b.index, action.Start, fmt.Errorf("unexpected else in the body of a %s", strings.ToLower(kind.String())))
+		} else if b.top.Data == "" {
+			return utpx.NewErrSyntax(b.index, action.Start, errors.New("unexpected else after the else branch"))
+		} else if cmd.Type == prx.TkElseIf && b.top.Parent.Kind != IfNode {
+			return utpx.NewErrSyntax(b.index, action.Start, errors.New("unexpected else if in a range block"))
+		}
+
+		// The variables of the previous branch are not in scope in this one.
+		b.vars = b.vars[:b.marks[len(b.marks)-1]]
+
+		var branch *Node
+
+		if pipe == nil {
+			branch = NewNode(BranchNode, "")
+		} else {
+			branch = NewNode(BranchNode, pipe.Data)
+			branch.Pipe = pipe
+		}
+
+		add_child(b.top.Parent, branch)
+
+		b.top = branch
+	case prx.TkNote:
+		data, err := leaf_data(cmd, prx.TkComment)
+		if err != nil {
+			return err
+		}
+
+		add_child(b.top, NewNode(CommentNode, data))
+	case prx.TkDefine:
+		if b.top != b.root {
+			return utpx.NewErrSyntax(b.index, action.Start, errors.New("unexpected define inside a block"))
+		}
+
+		name, err := b.template_name(cmd)
+		if err != nil {
+			return err
+		}
+
+		node := NewNode(DefineNode, name)
+		node.Pos = action.Start
+		add_child(b.top, node)
+
+		branch := NewNode(BranchNode, name)
+		add_child(node, branch)
+
+		b.open(action, branch, nil)
+		b.bases = append(b.bases, len(b.vars))
+	case prx.TkInclude:
+		name, err := b.template_name(cmd)
+		if err != nil {
+			return err
+		}
+
+		node := NewNode(TemplateNode, name)
+		node.Pipe = pipe
+		node.Pos = action.Start
+
+		add_child(b.top, node)
+	case prx.TkBlock:
+		name, err := b.template_name(cmd)
+		if err != nil {
+			return err
+		}
+
+		node := NewNode(BlockNode, name)
+		node.Pipe = pipe
+		node.Pos = action.Start
+		add_child(b.top, node)
+
+		branch := NewNode(BranchNode, pipe.Data)
+		add_child(node, branch)
+
+		b.open(action, branch, nil)
+		b.bases = append(b.bases, len(b.vars))
+	case prx.TkEnd:
+		if b.top == b.root {
+			return utpx.NewErrSyntax(b.index, action.Start, errors.New("unexpected end outside of a block"))
+		}
+
+		if kind := b.top.Parent.Kind; kind == DefineNode || kind == BlockNode {
+			b.bases = b.bases[:len(b.bases)-1]
+		}
+
+		b.top = b.top.Parent.Parent
+		b.opens = b.opens[:len(b.opens)-1]
+		b.vars = b.vars[:b.marks[len(b.marks)-1]]
+		b.marks = b.marks[:len(b.marks)-1]
+	default:
+		return utpx.NewErrExpected(&cmd.Type, nil, prx.TkVariable, prx.TkAssign, prx.TkIf, prx.TkElseIf, prx.TkElse, prx.TkRange, prx.TkEnd, prx.TkNote, prx.TkDefine, prx.TkInclude, prx.TkBlock)
+	}
+
+	return nil
+}
+
+// trim_cutset is the whitespace that the trim markers remove from the text around an action.
+const trim_cutset string = " \t\r\n"
+
+// is_trimmed is a helper function that checks whether a delimiter of an action is a trim
+// marker.
+//
+// Parameters:
+//   - action: The action.
+//   - delim: The type of the delimiter. Either prx.TkOpen or prx.TkClose.
+//   - marker: The type of the trim marker. Either prx.TkOpTrim or prx.TkClTrim.
+//
+// Returns:
+//   - bool: True if the delimiter is the trim marker, false otherwise.
+//   - error: An error if the action has no such delimiter.
+func is_trimmed(action *utpx.Token[prx.TokenType], delim, marker prx.TokenType) (bool, error) {
+	tk, err := child_of(action, delim)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = child_of(tk, marker)
+
+	return err == nil, nil
+}
+
+// trim_last is a helper function that trims the whitespace at the end of the text right
+// before the current action, if any. The text is removed if only whitespace is left.
+func (b *ast_builder) trim_last() {
+	if len(b.top.Children) == 0 {
+		return
+	}
+
+	last := b.top.Children[len(b.top.Children)-1]
+	if last.Kind != TextNode {
+		return
+	}
+
+	last.Data = strings.TrimRight(last.Data, trim_cutset)
+
+	if last.Data == "" {
+		b.top.Children = b.top.Children[:len(b.top.Children)-1]
+	}
+}
+
+// open is a helper function that opens a block.
+//
+// Parameters:
+//   - action: The action that opens the block.
+//   - branch: The first branch of the block.
+//   - vars: The variables declared by the block.
+func (b *ast_builder) open(action *utpx.Token[prx.TokenType], branch *Node, vars []string) {
+	b.top = branch
+	b.opens = append(b.opens, action)
+	b.marks = append(b.marks, len(b.vars))
+	b.vars = append(b.vars, vars...)
+}
+
+// check_var is a helper function that checks that the variable a field path starts with,
+// if any, is in scope.
+//
+// Parameters:
+//   - path: The field path.
+//   - tk: The token of the field path.
+//
+// Returns:
+//   - error: An *utpx.ErrSyntax if the variable is not in scope.
+func (b *ast_builder) check_var(path string, tk *utpx.Token[prx.TokenType]) error {
+	name, _, _ := strings.Cut(path, ".")
+	if name == "" || name == "$" {
+		return nil
+	}
+
+	vars := b.vars
+
+	if len(b.bases) > 0 {
+		vars = vars[b.bases[len(b.bases)-1]:]
+	}
+
+	if slices.Contains(vars, name) {
+		return nil
+	}
+
+	return utpx.NewErrSyntax(b.index, tk.Start, fmt.Errorf("undefined variable %s", name))
+}
+
+// check_call is a helper function that checks that a function is defined and that it can
+// take the given number of arguments.
+//
+// Parameters:
+//   - name: The name of the function.
+//   - nargs: The number of arguments of the call.
+//   - tk: The token of the call.
+//
+// Returns:
+//   - error: An *utpx.ErrSyntax if the function cannot be called.
+func (b *ast_builder) check_call(name string, nargs int, tk *utpx.Token[prx.TokenType]) error {
+	fn, ok := b.funcs[name]
+	if !ok {
+		return utpx.NewErrSyntax(b.index, tk.Start, fmt.Errorf("function %q is not defined", name))
+	}
+
+	err := check_arity(name, fn, nargs)
+	if err != nil {
+		return utpx.NewErrSyntax(b.index, tk.Start, err)
+	}
+
+	return nil
+}
+
+// to_pipeline is a helper function that converts a pipeline token into a pipeline node.
+//
+// Parameters:
+//   - root: The pipeline token.
+//
+// Returns:
+//   - *Node: The pipeline node; its data is the text of the pipeline.
+//   - error: An error if the pipeline is invalid.
+func (b *ast_builder) to_pipeline(root *utpx.Token[prx.TokenType]) (*Node, error) {
+	children, ok := root.Data.([]*utpx.Token[prx.TokenType])
+	if !ok {
+		return nil, fmt.Errorf("expected %q to be a non-leaf node, got a leaf node instead", root.String())
+	}
+
+	pipe := NewNode(PipelineNode, "")
+
+	var texts []string
+
+	for _, child := range children {
+		if child.Type != prx.TkCall {
+			continue
+		}
+
+		stage, err := b.to_stage(child, len(pipe.Children) > 0)
+		if err != nil {
+			return nil, err
+		}
+
+		add_child(pipe, stage)
+
+		texts = append(texts, node_text(stage))
+	}
+
+	if len(pipe.Children) == 0 {
+		return nil, fmt.Errorf("expected %q to have a call", root.String())
+	}
+
+	pipe.Data = strings.Join(texts, " | ")
+
+	return pipe, nil
+}
+
+// to_stage is a helper function that converts a call token into a stage of a pipeline.
+//
+// Parameters:
+//   - root: The call token.
+//   - piped: Whether the stage receives the value of the previous stage.
+//
+// Returns:
+//   - *Node: The stage. Either a call, a field or a literal node.
+//   - error: An error if the stage is invalid.
+func (b *ast_builder) to_stage(root *utpx.Token[prx.TokenType], piped bool) (*Node, error) {
+	children, ok := root.Data.([]*utpx.Token[prx.TokenType])
+	if !ok {
+		return nil, fmt.Errorf("expected %q to be a non-leaf node, got a leaf node instead", root.String())
+	}
+
+	var operands []*utpx.Token[prx.TokenType]
+
+	for _, child := range children {
+		sub, ok := child.Data.([]*utpx.Token[prx.TokenType])
+		if !ok || child.Type != prx.TkOperand || len(sub) != 1 {
+			return nil, fmt.Errorf("expected %q to be an operand", child.String())
+		}
+
+		operands = append(operands, sub[0])
+	}
+
+	if len(operands) == 0 {
+		return nil, fmt.Errorf("expected %q to have an operand", root.String())
+	}
+
+	first := operands[0]
+
+	if first.Type != prx.TkFunc {
+		if len(operands) > 1 {
+			return nil, utpx.NewErrSyntax(b.index, operands[1].Start, errors.New("cannot give arguments to a value that is not a function"))
+		} else if piped {
+			return nil, utpx.NewErrSyntax(b.index, first.Start, errors.New("cannot pipe into a value that is not a function"))
+		}
+
+		return b.to_operand(first)
+	}
+
+	name, err := leaf_data(first, prx.TkIdent)
+	if err != nil {
+		return nil, err
+	}
+
+	nargs := len(operands) - 1
+
+	if piped {
+		nargs++
+	}
+
+	err = b.check_call(name, nargs, first)
+	if err != nil {
+		return nil, err
+	}
+
+	call := NewNode(CallNode, name)
+
+	for _, operand := range operands[1:] {
+		arg, err := b.to_operand(operand)
+		if err != nil {
+			return nil, err
+		}
+
+		add_child(call, arg)
+	}
+
+	return call, nil
+}
+
+// to_operand is a helper function that converts an operand token into a node.
+//
+// Parameters:
+//   - root: The field, literal or function token of the operand. A function operand is a
+//     call without arguments.
+//
+// Returns:
+//   - *Node: The node of the operand.
+//   - error: An error if the operand is invalid.
+func (b *ast_builder) to_operand(root *utpx.Token[prx.TokenType]) (*Node, error) {
+	switch root.Type {
+	case prx.TkField:
+		path, err := leaf_data(root, prx.TkPath, prx.TkVar)
+		if err != nil {
+			return nil, err
+		}
+
+		err = b.check_var(path, root)
+		if err != nil {
+			return nil, err
+		}
+
+		node := NewNode(FieldNode, path)
+		node.Pos = root.Start
+
+		return node, nil
+	case prx.TkLiteral:
+		text, err := leaf_data(root, prx.TkString, prx.TkNumber, prx.TkKwTrue, prx.TkKwFalse)
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = literal_value(text)
+		if err != nil {
+			return nil, utpx.NewErrSyntax(b.index, root.Start, err)
+		}
+
+		return NewNode(LiteralNode, text), nil
+	case prx.TkFunc:
+		name, err := leaf_data(root, prx.TkIdent)
+		if err != nil {
+			return nil, err
+		}
+
+		err = b.check_call(name, 0, root)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewNode(CallNode, name), nil
+	default:
+		return nil, utpx.NewErrExpected(&root.Type, nil, prx.TkField, prx.TkLiteral, prx.TkFunc)
+	}
+}
+
+// leaf_data is a helper function that returns the data of the first child of a token that
+// has one of the given types.
+//
+// Parameters:
+//   - root: The token.
+//   - types: The types of the child.
+//
+// Returns:
+//   - string: The data of the child.
+//   - error: An error if the token has no such child.
+func leaf_data(root *utpx.Token[prx.TokenType], types ...prx.TokenType) (string, error) {
+	for _, typ := range types {
+		child, err := child_of(root, typ)
+		if err != nil {
+			continue
+		}
+
+		data, ok := child.Data.(string)
+		if !ok {
+			return "", fmt.Errorf("expected %q to be a leaf node, got a non-leaf node instead", child.String())
+		}
+
+		return data, nil
+	}
+
+	return "", fmt.Errorf("expected %q to have a %s", root.String(), types[0].String())
+}
+
+// node_text is a helper function that returns the text of a stage of a pipeline or of an
+// operand.
+//
+// Parameters:
+//   - node: The node.
+//
+// Returns:
+//   - string: The text of the node. (e.g., "printf \"%s\" .Name")
+func node_text(node *Node) string {
+	if node.Kind != CallNode {
+		return node.Data
+	}
+
+	texts := []string{node.Data}
+
+	for _, arg := range node.Children {
+		texts = append(texts, node_text(arg))
+	}
+
+	return strings.Join(texts, " ")
+}
+
+// finish returns the AST once every element was added.
+//
+// Returns:
+//   - *Node: The AST. Never returns nil.
+//   - error: An *utpx.ErrSyntax if a block is not closed.
+func (b *ast_builder) finish() (*Node, error) {
+	if len(b.opens) > 0 {
+		open := b.opens[len(b.opens)-1]
+		kind := strings.ToLower(b.top.Parent.Kind.String())
+
+		if kind != "block" {
+			kind += " block"
+		}
+
+		return nil, utpx.NewErrSyntax(b.index, open.Start, fmt.Errorf("unclosed %s; expected %s", kind, b.end))
+	}
+
+	return b.root, nil
+}
+
+// template_name is a helper function that returns the name of the sub-template of a
+// define, a template or a block action.
+//
+// Parameters:
+//   - root: The define, include or block token.
+//
+// Returns:
+//   - string: The name of the sub-template. (e.g., "header")
+//   - error: An *utpx.ErrSyntax if the name is not a valid string literal.
+func (b *ast_builder) template_name(root *utpx.Token[prx.TokenType]) (string, error) {
+	tk, err := child_of(root, prx.TkString)
+	if err != nil {
+		return "", err
+	}
+
+	text, ok := tk.Data.(string)
+	if !ok {
+		return "", fmt.Errorf("expected %q to be a leaf node, got a non-leaf node instead", tk.String())
+	}
+
+	name, err := strconv.Unquote(text)
+	if err != nil {
+		return "", utpx.NewErrSyntax(b.index, tk.Start, fmt.Errorf("invalid template name %s: %w", text, err))
+	}
+
+	return name, nil
+}
+
+// decl_var is a helper function that returns the name of a declared variable.
+//
+// Parameters:
+//   - tk: The var token.
+//
+// Returns:
+//   - string: The name of the variable. (e.g., "$v")
+//   - error: An *utpx.ErrSyntax if the variable cannot be declared.
+func (b *ast_builder) decl_var(tk *utpx.Token[prx.TokenType]) (string, error) {
+	name, ok := tk.Data.(string)
+	if !ok {
+		return "", fmt.Errorf("expected %q to be a leaf node, got a non-leaf node instead", tk.String())
+	}
+
+	if name == "$" || strings.Contains(name, ".") {
+		return "", utpx.NewErrSyntax(b.index, tk.Start, fmt.Errorf("cannot declare %s", name))
+	}
+
+	return name, nil
+}
+
+// decl_vars is a helper function that returns the variables declared by a range.
+//
+// Parameters:
+//   - root: The range token.
+//
+// Returns:
+//   - []string: The variables; the index (or key) first. Nil if there are none.
+//   - error: An error if the token is invalid.
+func (b *ast_builder) decl_vars(root *utpx.Token[prx.TokenType]) ([]string, error) {
+	decl, err := child_of(root, prx.TkDecl)
+	if err != nil {
+		// The range declares no variable.
+		return nil, nil
+	}
+
+	children, ok := decl.Data.([]*utpx.Token[prx.TokenType])
+	if !ok {
+		return nil, fmt.Errorf("expected %q to be a non-leaf node, got a leaf node instead", decl.String())
+	}
+
+	var vars []string
+
+	for _, child := range children {
+		if child.Type != prx.TkVar {
+			continue
+		}
+
+		name, err := b.decl_var(child)
+		if err != nil {
+			return nil, err
+		}
+
+		vars = append(vars, name)
+	}
+
+	return vars, nil
+}
+
+// simplify_ast is a helper function to simplify the AST.
+//
+// Parameters:
+//   - root: The root node of the tree.
+//
+// Returns:
+//   - bool: True if the tree is simplified, false otherwise.
+//
+// Assertions:
+//   - The root node must not be nil.
+//   - No node in the tree can be nil.
+func simplify_ast(root *Node) bool {
+	uc.AssertParam("root", root != nil, errors.New("root must not be nil"))
+
+	for _, child := range root.Children {
+		ok := simplify_ast(child)
+		if ok {
+			return true
+		}
+	}
+
+	idx := -1
+
+	for i := 0; i < len(root.Children)-1; i++ {
+		first := root.Children[i]
+		second := root.Children[i+1]
+
+		if first.Kind == TextNode && second.Kind == TextNode {
+			idx = i
+			break
+		}
+	}
+
+	if idx == -1 {
+		return false
+	}
+
+	root.Children[idx+1].Data = root.Children[idx].Data + root.Children[idx+1].Data
+
+	root.Children = slices.Delete(root.Children, idx, idx+1)
+
+	return true
+}
+
+// PrintAST is a debug function to print the AST.
+//
+// Parameters:
+//   - node: The root node of the tree.
+//
+// Returns:
+//   - string: The string representation of the AST.
+//
+// Assertions:
+//   - Printing the tree should never fail.
+func PrintAST(node *Node) string {
+	if node == nil {
+		return ""
+	}
+
+	str, err := fstr.PrintTree(node)
+	uc.AssertErr(err, "tree.PrintTree(%s)", node.String())
+
+	return str
+}
@@ -0,0 +1,339 @@
+package pkg
+
+import (
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	ggen "github.com/PlayerR9/lib_units/generator"
+)
+
+// DefaultFuncs returns the functions that every template can call. Functions given with
+// WithFuncs override them.
+//
+// The functions are:
+//   - camel: Converts a name to camelCase. (e.g., "linked_stack" -> "linkedStack")
+//   - pascal: Converts a name to PascalCase. (e.g., "linked_stack" -> "LinkedStack")
+//   - snake: Converts a name to snake_case. (e.g., "LinkedStack" -> "linked_stack")
+//   - quote: Quotes a string as a Go string literal.
+//   - is_valid_name: Checks that a name is a valid Go identifier that is not a keyword.
+//   - receiver: Derives the receiver name of a type. (e.g., "*LinkedStack[T]" -> "ls")
+//   - plural: Returns the plural form of a word. (e.g., "entry" -> "entries")
+//   - zero: Returns the zero value of a type expression. (e.g., "*int" -> "nil")
+//   - wrap: Wraps a text into doc-comment lines of at most the given width.
+//     (e.g., {{ .Doc | wrap 80 }})
+//   - default: Returns the value or, if it is empty, the default value. With the
+//     MissingDefault policy, it also replaces fields that cannot be resolved.
+//     (e.g., {{ .Type | default "T" }})
+//
+// Returns:
+//   - FuncMap: A new map of the functions. Never returns nil.
+func DefaultFuncs() FuncMap {
+	return FuncMap{
+		"camel":         CamelCase,
+		"pascal":        PascalCase,
+		"snake":         SnakeCase,
+		"quote":         strconv.Quote,
+		"is_valid_name": IsValidName,
+		"receiver":      ReceiverName,
+		"plural":        Plural,
+		"zero":          ggen.ZeroValueOf,
+		"wrap":          WrapComment,
+		"default":       DefaultValue,
+	}
+}
+
+// split_words is a helper function that splits a name into its words. Words are separated
+// by any character that is neither a letter nor a digit and by changes of case; a run of
+// uppercase letters is an acronym. (e.g., "HTTPServer_v2" -> ["HTTP", "Server", "v2"])
+//
+// Parameters:
+//   - name: The name to split.
+//
+// Returns:
+//   - []string: The words of the name.
+func split_words(name string) []string {
+	runes := []rune(name)
+
+	var words []string
+
+	start := -1
+
+	for i, c := range runes {
+		if !unicode.IsLetter(c) && !unicode.IsDigit(c) {
+			if start != -1 {
+				words = append(words, string(runes[start:i]))
+				start = -1
+			}
+
+			continue
+		}
+
+		if start == -1 {
+			start = i
+
+			continue
+		}
+
+		prev := runes[i-1]
+
+		var is_boundary bool
+
+		if unicode.IsUpper(c) {
+			// "linkedStack" or the end of an acronym such as the "S" of "HTTPServer".
+			is_boundary = !unicode.IsUpper(prev) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))
+		}
+
+		if is_boundary {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+
+	if start != -1 {
+		words = append(words, string(runes[start:]))
+	}
+
+	return words
+}
+
+// upper_first is a helper function that uppercases the first letter of a word.
+//
+// Parameters:
+//   - word: The word.
+//
+// Returns:
+//   - string: The word with its first letter in uppercase.
+func upper_first(word string) string {
+	r, size := utf8.DecodeRuneInString(word)
+	if r == utf8.RuneError {
+		return word
+	}
+
+	return string(unicode.ToUpper(r)) + word[size:]
+}
+
+// PascalCase converts a name to PascalCase. Acronyms are kept as they are.
+//
+// Parameters:
+//   - name: The name. (e.g., "linked_stack")
+//
+// Returns:
+//   - string: The name in PascalCase. (e.g., "LinkedStack")
+func PascalCase(name string) string {
+	var builder strings.Builder
+
+	for _, word := range split_words(name) {
+		builder.WriteString(upper_first(word))
+	}
+
+	return builder.String()
+}
+
+// CamelCase converts a name to camelCase. Acronyms are kept as they are except for the
+// first word which is lowercased.
+//
+// Parameters:
+//   - name: The name. (e.g., "linked_stack")
+//
+// Returns:
+//   - string: The name in camelCase. (e.g., "linkedStack")
+func CamelCase(name string) string {
+	words := split_words(name)
+	if len(words) == 0 {
+		return ""
+	}
+
+	var builder strings.Builder
+
+	builder.WriteString(strings.ToLower(words[0]))
+
+	for _, word := range words[1:] {
+		builder.WriteString(upper_first(word))
+	}
+
+	return builder.String()
+}
+
+// SnakeCase converts a name to snake_case.
+//
+// Parameters:
+//   - name: The name. (e.g., "LinkedStack")
+//
+// Returns:
+//   - string: The name in snake_case. (e.g., "linked_stack")
+func SnakeCase(name string) string {
+	words := split_words(name)
+
+	for i, word := range words {
+		words[i] = strings.ToLower(word)
+	}
+
+	return strings.Join(words, "_")
+}
+
+// IsValidName checks that a name is a valid Go identifier that is not a keyword. These are
+// the rules of the generator's IsValidName with either casing allowed.
+//
+// Parameters:
+//   - name: The name.
+//
+// Returns:
+//   - bool: True if the name is valid, false otherwise.
+func IsValidName(name string) bool {
+	err := ggen.IsValidName(name, nil, ggen.Either)
+	if err != nil {
+		return false
+	}
+
+	for i, c := range name {
+		if c == '_' || unicode.IsLetter(c) || (i > 0 && unicode.IsDigit(c)) {
+			continue
+		}
+
+		return false
+	}
+
+	return !slices.Contains(ggen.GoReservedKeywords, name)
+}
+
+// type_name is a helper function that returns the name of the type of a type expression;
+// without pointers, slices, package qualifiers nor generics.
+//
+// Parameters:
+//   - expr: The type expression. (e.g., "*ds.LinkedStack[T]")
+//
+// Returns:
+//   - string: The name of the type. (e.g., "LinkedStack")
+func type_name(expr string) string {
+	expr = strings.TrimLeft(strings.TrimSpace(expr), "*[]")
+
+	idx := strings.IndexByte(expr, '[')
+	if idx != -1 {
+		expr = expr[:idx]
+	}
+
+	idx = strings.LastIndexByte(expr, '.')
+	if idx != -1 {
+		expr = expr[idx+1:]
+	}
+
+	return expr
+}
+
+// ReceiverName derives the name of the receiver of the methods of a type; that is, the
+// initials of the words of its name in lowercase. If that is a keyword, only the first
+// initial is used.
+//
+// Parameters:
+//   - expr: The type expression. (e.g., "*LinkedStack[T]")
+//
+// Returns:
+//   - string: The receiver name. (e.g., "ls") Empty if the type has no name.
+func ReceiverName(expr string) string {
+	var builder strings.Builder
+
+	for _, word := range split_words(type_name(expr)) {
+		r, _ := utf8.DecodeRuneInString(word)
+
+		builder.WriteRune(unicode.ToLower(r))
+	}
+
+	name := builder.String()
+
+	if slices.Contains(ggen.GoReservedKeywords, name) {
+		r, _ := utf8.DecodeRuneInString(name)
+
+		return string(r)
+	}
+
+	return name
+}
+
+// Plural returns the plural form of an English word with the regular rules.
+//
+// Parameters:
+//   - word: The word. (e.g., "entry", "box" or "stack")
+//
+// Returns:
+//   - string: The plural form. (e.g., "entries", "boxes" or "stacks")
+func Plural(word string) string {
+	lower := strings.ToLower(word)
+
+	switch {
+	case word == "":
+		return ""
+	case strings.HasSuffix(lower, "y") && len(lower) > 1 && !strings.ContainsRune("aeiou", rune(lower[len(lower)-2])):
+		return word[:len(word)-1] + "ies"
+	case strings.HasSuffix(lower, "s"), strings.HasSuffix(lower, "x"), strings.HasSuffix(lower, "z"),
+		strings.HasSuffix(lower, "ch"), strings.HasSuffix(lower, "sh"):
+		return word + "es"
+	default:
+		return word + "s"
+	}
+}
+
+// WrapComment wraps a text into doc-comment lines; each line starts with "// " and is at
+// most width characters long unless a single word is longer. Paragraphs (separated by
+// blank lines) are kept.
+//
+// Parameters:
+//   - width: The maximum length of a line; including the "// " prefix.
+//   - text: The text to wrap.
+//
+// Returns:
+//   - string: The comment lines joined with newlines; without a trailing newline.
+func WrapComment(width int, text string) string {
+	const prefix string = "// "
+
+	var lines []string
+
+	for i, paragraph := range strings.Split(strings.TrimSpace(text), "\n\n") {
+		if i > 0 {
+			lines = append(lines, "//")
+		}
+
+		var builder strings.Builder
+
+		builder.WriteString(prefix)
+
+		for _, word := range strings.Fields(paragraph) {
+			if builder.Len() > len(prefix) && builder.Len()+1+len(word) > width {
+				lines = append(lines, builder.String())
+
+				builder.Reset()
+				builder.WriteString(prefix)
+			}
+
+			if builder.Len() > len(prefix) {
+				builder.WriteRune(' ')
+			}
+
+			builder.WriteString(word)
+		}
+
+		lines = append(lines, strings.TrimRight(builder.String(), " "))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// DefaultValue returns the value or, if it is empty, the default value. A value is empty
+// if it is false as the condition of an if block. (e.g., nil, 0 or "")
+//
+// Parameters:
+//   - def: The default value.
+//   - value: The value.
+//
+// Returns:
+//   - any: The value, or the default value if it is empty.
+func DefaultValue(def, value any) any {
+	if is_true(reflect.ValueOf(value)) {
+		return value
+	}
+
+	return def
+}
@@ -0,0 +1,132 @@
+package pkg
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCaseConversion(t *testing.T) {
+	tests := []struct {
+		name   string
+		camel  string
+		pascal string
+		snake  string
+	}{
+		{"linked_stack", "linkedStack", "LinkedStack", "linked_stack"},
+		{"LinkedStack", "linkedStack", "LinkedStack", "linked_stack"},
+		{"HTTPServer", "httpServer", "HTTPServer", "http_server"},
+		{"node id", "nodeId", "NodeId", "node_id"},
+		{"token2-type", "token2Type", "Token2Type", "token2_type"},
+		{"", "", "", ""},
+	}
+
+	for _, test := range tests {
+		if res := CamelCase(test.name); res != test.camel {
+			t.Errorf("camel(%q): expected %q, got %q", test.name, test.camel, res)
+		}
+
+		if res := PascalCase(test.name); res != test.pascal {
+			t.Errorf("pascal(%q): expected %q, got %q", test.name, test.pascal, res)
+		}
+
+		if res := SnakeCase(test.name); res != test.snake {
+			t.Errorf("snake(%q): expected %q, got %q", test.name, test.snake, res)
+		}
+	}
+}
+
+func TestIsValidName(t *testing.T) {
+	tests := map[string]bool{
+		"Stack":  true,
+		"_tmp":   true,
+		"elem2":  true,
+		"":       false,
+		"2elem":  false,
+		"my-var": false,
+		"range":  false,
+		"type":   false,
+	}
+
+	for name, expected := range tests {
+		if res := IsValidName(name); res != expected {
+			t.Errorf("is_valid_name(%q): expected %t, got %t", name, expected, res)
+		}
+	}
+}
+
+func TestReceiverAndPlural(t *testing.T) {
+	receivers := map[string]string{
+		"*LinkedStack[T]": "ls",
+		"ds.Queue":        "q",
+		"[]token":         "t",
+		"IfFunc":          "i",
+	}
+
+	for expr, expected := range receivers {
+		if res := ReceiverName(expr); res != expected {
+			t.Errorf("receiver(%q): expected %q, got %q", expr, expected, res)
+		}
+	}
+
+	plurals := map[string]string{
+		"stack": "stacks",
+		"entry": "entries",
+		"key":   "keys",
+		"box":   "boxes",
+		"match": "matches",
+		"Class": "Classes",
+	}
+
+	for word, expected := range plurals {
+		if res := Plural(word); res != expected {
+			t.Errorf("plural(%q): expected %q, got %q", word, expected, res)
+		}
+	}
+}
+
+func TestWrapComment(t *testing.T) {
+	text := "Push adds an element on top of the stack.\n\nIt never fails."
+
+	expected := strings.Join([]string{
+		"// Push adds an element",
+		"// on top of the stack.",
+		"//",
+		"// It never fails.",
+	}, "\n")
+
+	if res := WrapComment(24, text); res != expected {
+		t.Errorf("expected %q, got %q", expected, res)
+	}
+}
+
+func TestDefaultFuncs(t *testing.T) {
+	type GenData struct {
+		Type string
+		Doc  string
+	}
+
+	data := GenData{Type: "*linked_stack", Doc: "A stack."}
+
+	str := `{{ .Doc | wrap 80 }}
+func ({{ receiver .Type }} {{ .Type | pascal }}) {{ "entry" | plural | pascal }}() {{ zero "[]int" }} {{ quote "a\"b" }} {{ is_valid_name "if" }}`
+
+	res, err := execute(t, str, data)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	expected := "// A stack.\nfunc (ls LinkedStack) Entries() nil \"a\\\"b\" false"
+
+	if res != expected {
+		t.Errorf("expected %q, got %q", expected, res)
+	}
+
+	res, err = execute_with(t, `{{ quote "x" }}`, struct{}{}, WithFuncs(FuncMap{"quote": strings.ToUpper}))
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	if res != "X" {
+		t.Errorf("expected user functions to override the defaults, got %q", res)
+	}
+}
@@ -0,0 +1,164 @@
+package pkg
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	utpx "github.com/PlayerR9/go_generator/util/parsing"
+)
+
+// ErrField is an error that occurs when a field path of the template cannot be resolved
+// against the data.
+type ErrField struct {
+	// Path is the full field path. (e.g., ".Type.Name" or "$v.Name")
+	Path string
+
+	// Field is the segment of the path that could not be resolved.
+	Field string
+
+	// Reason is the reason of the error.
+	Reason error
+
+	// Pos is the position of the path in the template. Its line is 0 if unknown.
+	Pos utpx.Position
+}
+
+// Error implements the error interface.
+//
+// Message: "{{ .Pos }}: cannot resolve {{ .Field }} of {{ .Path }}: {{ .Reason }}"
+//
+// The position is omitted when unknown.
+func (e *ErrField) Error() string {
+	var builder strings.Builder
+
+	if e.Pos.IsValid() {
+		builder.WriteString(e.Pos.String())
+		builder.WriteString(": ")
+	}
+
+	builder.WriteString("cannot resolve ")
+	builder.WriteString(strconv.Quote(e.Field))
+	builder.WriteString(" of ")
+	builder.WriteString(strconv.Quote(e.Path))
+
+	if e.Reason != nil {
+		builder.WriteString(": ")
+		builder.WriteString(e.Reason.Error())
+	}
+
+	return builder.String()
+}
+
+// Unwrap returns the reason of the error.
+//
+// Returns:
+//   - error: The reason of the error.
+func (e *ErrField) Unwrap() error {
+	return e.Reason
+}
+
+// NewErrField creates a new error.
+//
+// Parameters:
+//   - path: The full field path.
+//   - field: The segment of the path that could not be resolved.
+//   - reason: The reason of the error.
+//
+// Returns:
+//   - *ErrField: The error. Never returns nil.
+func NewErrField(path, field string, reason error) *ErrField {
+	return &ErrField{
+		Path:   path,
+		Field:  field,
+		Reason: reason,
+	}
+}
+
+// ErrCall is an error that occurs when a function of the template cannot be called or when
+// it fails.
+type ErrCall struct {
+	// Name is the name of the function.
+	Name string
+
+	// Reason is the reason of the error.
+	Reason error
+}
+
+// Error implements the error interface.
+//
+// Message: "calling {{ .Name }}: {{ .Reason }}"
+func (e *ErrCall) Error() string {
+	var builder strings.Builder
+
+	builder.WriteString("calling ")
+	builder.WriteString(e.Name)
+
+	if e.Reason != nil {
+		builder.WriteString(": ")
+		builder.WriteString(e.Reason.Error())
+	}
+
+	return builder.String()
+}
+
+// Unwrap returns the reason of the error.
+//
+// Returns:
+//   - error: The reason of the error.
+func (e *ErrCall) Unwrap() error {
+	return e.Reason
+}
+
+// NewErrCall creates a new error.
+//
+// Parameters:
+//   - name: The name of the function.
+//   - reason: The reason of the error.
+//
+// Returns:
+//   - *ErrCall: The error. Never returns nil.
+func NewErrCall(name string, reason error) *ErrCall {
+	return &ErrCall{
+		Name:   name,
+		Reason: reason,
+	}
+}
+
+// ErrFormat is an error that occurs when a value of the data cannot be written as text;
+// that is, when its kind is not supported and it implements neither fmt.Stringer nor
+// fmt.GoStringer.
+type ErrFormat struct {
+	// Type is the type of the value.
+	Type reflect.Type
+}
+
+// Error implements the error interface.
+//
+// Message: "cannot format a value of type {{ .Type }}"
+func (e *ErrFormat) Error() string {
+	var builder strings.Builder
+
+	builder.WriteString("cannot format a value of type ")
+
+	if e.Type == nil {
+		builder.WriteString("<nil>")
+	} else {
+		builder.WriteString(e.Type.String())
+	}
+
+	return builder.String()
+}
+
+// NewErrFormat creates a new error.
+//
+// Parameters:
+//   - typ: The type of the value.
+//
+// Returns:
+//   - *ErrFormat: The error. Never returns nil.
+func NewErrFormat(typ reflect.Type) *ErrFormat {
+	return &ErrFormat{
+		Type: typ,
+	}
+}
@@ -0,0 +1,304 @@
+package pkg
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// FuncMap maps the names of functions to the functions that templates can call.
+//
+// A function can take any number of arguments (it may be variadic) and must return either
+// a single value or a value and an error. A non-nil error stops the execution of the
+// template.
+type FuncMap map[string]any
+
+// error_type is the type of the error interface.
+var error_type reflect.Type = reflect.TypeOf((*error)(nil)).Elem()
+
+// check_signature is a helper function that checks that a function can be called by
+// templates.
+//
+// Parameters:
+//   - name: The name of the function.
+//   - fn: The function.
+//
+// Returns:
+//   - error: An error if the function cannot be called by templates.
+func check_signature(name string, fn any) error {
+	if !is_ident(name) {
+		return fmt.Errorf("%q is not a valid function name", name)
+	}
+
+	typ := reflect.TypeOf(fn)
+	if typ == nil || typ.Kind() != reflect.Func {
+		return fmt.Errorf("%s is not a function", name)
+	}
+
+	switch typ.NumOut() {
+	case 1:
+		return nil
+	case 2:
+		if typ.Out(1) == error_type {
+			return nil
+		}
+
+		return fmt.Errorf("the second result of %s must be an error, got %s instead", name, typ.Out(1).String())
+	default:
+		return fmt.Errorf("%s must return 1 or 2 values, got %d instead", name, typ.NumOut())
+	}
+}
+
+// is_ident is a helper function that checks if a string is an identifier of the
+// template language; that is, an identifier of Go that is not a keyword.
+//
+// Parameters:
+//   - name: The string to check.
+//
+// Returns:
+//   - bool: True if the string is an identifier, false otherwise.
+func is_ident(name string) bool {
+	if name == "" {
+		return false
+	}
+
+	for i, c := range name {
+		if c == '_' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') {
+			continue
+		} else if i > 0 && '0' <= c && c <= '9' {
+			continue
+		}
+
+		return false
+	}
+
+	switch name {
+	case "if", "else", "end", "range", "true", "false", "define", "template", "block":
+		return false
+	}
+
+	return true
+}
+
+// check_arity is a helper function that checks the number of arguments of a call.
+//
+// Parameters:
+//   - name: The name of the function.
+//   - fn: The function. Its signature must have been checked with check_signature.
+//   - nargs: The number of arguments of the call.
+//
+// Returns:
+//   - error: An error if the function cannot take that many arguments.
+func check_arity(name string, fn any, nargs int) error {
+	typ := reflect.TypeOf(fn)
+
+	if typ.IsVariadic() {
+		if nargs >= typ.NumIn()-1 {
+			return nil
+		}
+
+		return fmt.Errorf("wrong number of arguments for %s: expected at least %d, got %d", name, typ.NumIn()-1, nargs)
+	}
+
+	if nargs != typ.NumIn() {
+		return fmt.Errorf("wrong number of arguments for %s: expected %d, got %d", name, typ.NumIn(), nargs)
+	}
+
+	return nil
+}
+
+// is_numeric is a helper function that checks if a kind is a numeric kind.
+//
+// Parameters:
+//   - kind: The kind.
+//
+// Returns:
+//   - bool: True if the kind is numeric, false otherwise.
+func is_numeric(kind reflect.Kind) bool {
+	return (reflect.Int <= kind && kind <= reflect.Float64) && kind != reflect.Uintptr
+}
+
+// prepare_arg is a helper function that prepares an argument for a parameter of a function.
+//
+// Parameters:
+//   - arg: The argument. Invalid if nil.
+//   - typ: The type of the parameter.
+//
+// Returns:
+//   - reflect.Value: The argument as a value of the type of the parameter.
+//   - error: An error if the argument cannot be given to the parameter.
+func prepare_arg(arg reflect.Value, typ reflect.Type) (reflect.Value, error) {
+	if !arg.IsValid() {
+		switch typ.Kind() {
+		case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Pointer, reflect.Slice:
+			return reflect.Zero(typ), nil
+		}
+
+		return reflect.Value{}, fmt.Errorf("expected %s, got nil instead", typ.String())
+	}
+
+	for arg.Kind() == reflect.Interface && !arg.IsNil() && !arg.Type().AssignableTo(typ) {
+		arg = arg.Elem()
+	}
+
+	if arg.Type().AssignableTo(typ) {
+		return arg, nil
+	} else if is_numeric(arg.Kind()) && is_numeric(typ.Kind()) {
+		return convert_number(arg, typ)
+	}
+
+	return reflect.Value{}, fmt.Errorf("expected %s, got %s instead", typ.String(), arg.Type().String())
+}
+
+// convert_number is a helper function that converts a number to another numeric type.
+// Unlike a Go conversion, it fails rather than truncating a float, wrapping a negative
+// number into an unsigned type or overflowing. A float converted to another float may
+// lose precision.
+//
+// Parameters:
+//   - arg: The number.
+//   - typ: The numeric type.
+//
+// Returns:
+//   - reflect.Value: The number as a value of the type.
+//   - error: An error if the number cannot be represented by the type.
+func convert_number(arg reflect.Value, typ reflect.Type) (reflect.Value, error) {
+	conv := arg.Convert(typ)
+
+	var lost bool
+
+	if arg.CanFloat() && conv.CanFloat() {
+		lost = conv.OverflowFloat(arg.Float())
+	} else {
+		lost = is_negative(arg) != is_negative(conv) || !conv.Convert(arg.Type()).Equal(arg)
+	}
+
+	if lost {
+		return reflect.Value{}, fmt.Errorf("%v cannot be represented by %s", arg, typ.String())
+	}
+
+	return conv, nil
+}
+
+// is_negative is a helper function that checks if a number is negative.
+//
+// Parameters:
+//   - v: The number.
+//
+// Returns:
+//   - bool: True if the number is negative, false otherwise.
+func is_negative(v reflect.Value) bool {
+	switch {
+	case v.CanInt():
+		return v.Int() < 0
+	case v.CanFloat():
+		return v.Float() < 0
+	default:
+		return false
+	}
+}
+
+// call_func is a helper function that calls a function of a template.
+//
+// Parameters:
+//   - name: The name of the function.
+//   - fn: The function. Its signature must have been checked with check_signature.
+//   - args: The arguments.
+//
+// Returns:
+//   - reflect.Value: The result of the function.
+//   - error: An error of type *ErrCall if the function could not be called or if it
+//     failed.
+func call_func(name string, fn reflect.Value, args []reflect.Value) (result reflect.Value, err error) {
+	typ := fn.Type()
+
+	err = check_arity(name, fn.Interface(), len(args))
+	if err != nil {
+		return reflect.Value{}, NewErrCall(name, err)
+	}
+
+	in := make([]reflect.Value, 0, len(args))
+
+	for i, arg := range args {
+		var param reflect.Type
+
+		if typ.IsVariadic() && i >= typ.NumIn()-1 {
+			param = typ.In(typ.NumIn() - 1).Elem()
+		} else {
+			param = typ.In(i)
+		}
+
+		value, err := prepare_arg(arg, param)
+		if err != nil {
+			return reflect.Value{}, NewErrCall(name, fmt.Errorf("wrong type for argument %d: %w", i+1, err))
+		}
+
+		in = append(in, value)
+	}
+
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+
+		reason, ok := r.(error)
+		if !ok {
+			reason = fmt.Errorf("%v", r)
+		}
+
+		result = reflect.Value{}
+		err = NewErrCall(name, fmt.Errorf("panic: %w", reason))
+	}()
+
+	out := fn.Call(in)
+
+	if len(out) == 2 && !out[1].IsNil() {
+		reason, _ := out[1].Interface().(error)
+
+		return reflect.Value{}, NewErrCall(name, reason)
+	}
+
+	return out[0], nil
+}
+
+// new_funcs is a helper function that checks and copies the functions of a template.
+//
+// Parameters:
+//   - funcs: The functions.
+//
+// Returns:
+//   - FuncMap: The copy of the functions. Never returns nil.
+//   - error: An error if a function cannot be called by templates. The errors of the
+//     functions are joined in the order of their names.
+func new_funcs(funcs FuncMap) (FuncMap, error) {
+	names := make([]string, 0, len(funcs))
+
+	for name := range funcs {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	copied := make(FuncMap, len(funcs))
+
+	var errs []error
+
+	for _, name := range names {
+		fn := funcs[name]
+
+		err := check_signature(name, fn)
+		if err != nil {
+			errs = append(errs, err)
+		} else {
+			copied[name] = fn
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return copied, nil
+}
@@ -0,0 +1,124 @@
+package pkg
+
+// Option is an option of a template.
+type Option func(t *Template)
+
+// WithFuncs adds functions that the template can call. Functions added later override those
+// with the same name.
+//
+// Parameters:
+//   - funcs: The functions. See FuncMap for the signatures they may have.
+//
+// Returns:
+//   - Option: The option. Never returns nil.
+func WithFuncs(funcs FuncMap) Option {
+	return func(t *Template) {
+		if t.funcs == nil {
+			t.funcs = make(FuncMap, len(funcs))
+		}
+
+		for name, fn := range funcs {
+			t.funcs[name] = fn
+		}
+	}
+}
+
+// WithSeparator sets the separator between the elements of an array or a slice when it is
+// written. Default is ", ".
+//
+// Parameters:
+//   - sep: The separator.
+//
+// Returns:
+//   - Option: The option. Never returns nil.
+func WithSeparator(sep string) Option {
+	return func(t *Template) {
+		t.sep = sep
+	}
+}
+
+// WithNilText sets the text written in place of a nil value. Default is the empty string.
+//
+// Parameters:
+//   - text: The text. (e.g., "<nil>" or "nil")
+//
+// Returns:
+//   - Option: The option. Never returns nil.
+func WithNilText(text string) Option {
+	return func(t *Template) {
+		t.nil_text = text
+	}
+}
+
+// WithTagName sets the key of the struct tags that name the fields of the data. A field
+// path then refers to a field by the name in its tag (e.g., `json:"name,omitempty"`) or,
+// failing that, by its Go name.
+//
+// Parameters:
+//   - tag: The key of the struct tags. (e.g., "json" or "yaml")
+//
+// Returns:
+//   - Option: The option. Never returns nil.
+func WithTagName(tag string) Option {
+	return func(t *Template) {
+		t.tag = tag
+	}
+}
+
+// WithDelims sets the delimiters of the actions. They are used to lex the template, to
+// write back the actions that were not applied and in error messages. Default is "{{"
+// and "}}".
+//
+// Parameters:
+//   - open: The delimiter that opens an action. If empty, the default one is used.
+//   - close: The delimiter that closes an action. If empty, the default one is used.
+//
+// Returns:
+//   - Option: The option. Never returns nil.
+//
+// NewTemplate fails if a delimiter contains a whitespace.
+func WithDelims(open, close string) Option {
+	return func(t *Template) {
+		t.open = open
+		t.close = close
+	}
+}
+
+// MissingPolicy is what the execution of a template does with a field path that cannot be
+// resolved against the data.
+type MissingPolicy int
+
+const (
+	// MissingError stops the execution with an *ErrField that gives the path and its
+	// position. This is the default.
+	MissingError MissingPolicy = iota
+
+	// MissingZero makes the field empty; that is, written as the empty string (whatever
+	// the nil text), false as a condition and empty as a collection.
+	MissingZero
+
+	// MissingKeep keeps the action, or the block, that uses the field as it is written in
+	// the template.
+	MissingKeep
+
+	// MissingDefault makes the field nil when it is given to the default function; either
+	// as an argument or through a pipe. (e.g., {{ .Type | default "T" }}) Otherwise, the
+	// execution stops like with MissingError.
+	MissingDefault
+)
+
+// ExecOption is an option of an execution of a template.
+type ExecOption func(sc *scope)
+
+// OnMissing sets what the execution does with the field paths that cannot be resolved.
+//
+// Parameters:
+//   - policy: The policy. (e.g., MissingKeep)
+//
+// Returns:
+//   - ExecOption: The option. Never returns nil.
+func OnMissing(policy MissingPolicy) ExecOption {
+	return func(sc *scope) {
+		sc.missing = policy
+	}
+}
@@ -0,0 +1,34 @@
+package parsing
+
+import (
+	_ "embed"
+
+	utpx "github.com/PlayerR9/go_generator/util/parsing"
+	uc "github.com/PlayerR9/lib_units/common"
+)
+
+//go:generate go run ../../cmd/grammar -mode tokens -g grammar.ebnf -o token.go
+//go:generate go run ../../cmd/grammar -mode table -g grammar.ebnf -o grammar_table.go
+
+var (
+	// Grammar is the grammar of the template language. The token type in token.go and
+	// the decision table in grammar_table.go are generated from it; run "go generate"
+	// after changing it.
+	//
+	// Repetitions are desugared into the helpers Source1, Pipeline1, Call1 and Sws1.
+	//
+	//go:embed grammar.ebnf
+	Grammar string
+)
+
+var (
+	// DecisionTable is the decision table. Never nil.
+	DecisionTable *utpx.DecisionTable[TokenType]
+)
+
+func init() {
+	dt, err := utpx.NewStaticDecisionTable(StaticTable)
+	uc.AssertErr(err, "parsing.NewStaticDecisionTable(StaticTable)")
+
+	DecisionTable = dt
+}
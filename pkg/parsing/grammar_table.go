@@ -0,0 +1,837 @@
+// Code generated by go_generator. DO NOT EDIT.
+package parsing
+
+import (
+	utpx "github.com/PlayerR9/go_generator/util/parsing"
+)
+
+// StaticTable is the decision table of the grammar; computed when this file was generated.
+// Use utpx.NewStaticDecisionTable to load it.
+var StaticTable *utpx.StaticTable[TokenType] = &utpx.StaticTable[TokenType]{
+	Rules: []utpx.StaticRule[TokenType]{
+		{Lhs: TkSource, Rhss: []TokenType{TkElem, TkEOF}},
+		{Lhs: TkSource, Rhss: []TokenType{TkElem, TkSource1, TkEOF}},
+		{Lhs: TkSource1, Rhss: []TokenType{TkElem}},
+		{Lhs: TkSource1, Rhss: []TokenType{TkSource1, TkElem}},
+		{Lhs: TkElem, Rhss: []TokenType{TkAction}},
+		{Lhs: TkElem, Rhss: []TokenType{TkText}},
+		{Lhs: TkAction, Rhss: []TokenType{TkOpen, TkSws, TkCommand, TkClose}},
+		{Lhs: TkAction, Rhss: []TokenType{TkOpen, TkCommand, TkClose}},
+		{Lhs: TkOpen, Rhss: []TokenType{TkOpCurly}},
+		{Lhs: TkOpen, Rhss: []TokenType{TkOpTrim}},
+		{Lhs: TkClose, Rhss: []TokenType{TkClCurly}},
+		{Lhs: TkClose, Rhss: []TokenType{TkClTrim}},
+		{Lhs: TkCommand, Rhss: []TokenType{TkVariable}},
+		{Lhs: TkCommand, Rhss: []TokenType{TkAssign}},
+		{Lhs: TkCommand, Rhss: []TokenType{TkIf}},
+		{Lhs: TkCommand, Rhss: []TokenType{TkElseIf}},
+		{Lhs: TkCommand, Rhss: []TokenType{TkElse}},
+		{Lhs: TkCommand, Rhss: []TokenType{TkRange}},
+		{Lhs: TkCommand, Rhss: []TokenType{TkEnd}},
+		{Lhs: TkCommand, Rhss: []TokenType{TkNote}},
+		{Lhs: TkCommand, Rhss: []TokenType{TkDefine}},
+		{Lhs: TkCommand, Rhss: []TokenType{TkInclude}},
+		{Lhs: TkCommand, Rhss: []TokenType{TkBlock}},
+		{Lhs: TkVariable, Rhss: []TokenType{TkPipeline}},
+		{Lhs: TkAssign, Rhss: []TokenType{TkVar, TkSws, TkDeclare, TkSws, TkPipeline}},
+		{Lhs: TkAssign, Rhss: []TokenType{TkVar, TkSws, TkDeclare, TkPipeline}},
+		{Lhs: TkAssign, Rhss: []TokenType{TkVar, TkDeclare, TkSws, TkPipeline}},
+		{Lhs: TkAssign, Rhss: []TokenType{TkVar, TkDeclare, TkPipeline}},
+		{Lhs: TkIf, Rhss: []TokenType{TkKwIf, TkSws, TkPipeline}},
+		{Lhs: TkElseIf, Rhss: []TokenType{TkKwElse, TkSws, TkKwIf, TkSws, TkPipeline}},
+		{Lhs: TkElse, Rhss: []TokenType{TkKwElse, TkSws}},
+		{Lhs: TkElse, Rhss: []TokenType{TkKwElse}},
+		{Lhs: TkRange, Rhss: []TokenType{TkKwRange, TkSws, TkDecl, TkPipeline}},
+		{Lhs: TkRange, Rhss: []TokenType{TkKwRange, TkSws, TkPipeline}},
+		{Lhs: TkDecl, Rhss: []TokenType{TkVar, TkSws, TkComma, TkSws, TkVar, TkSws, TkDeclare, TkSws}},
+		{Lhs: TkDecl, Rhss: []TokenType{TkVar, TkSws, TkComma, TkSws, TkVar, TkSws, TkDeclare}},
+		{Lhs: TkDecl, Rhss: []TokenType{TkVar, TkSws, TkComma, TkSws, TkVar, TkDeclare, TkSws}},
+		{Lhs: TkDecl, Rhss: []TokenType{TkVar, TkSws, TkComma, TkSws, TkVar, TkDeclare}},
+		{Lhs: TkDecl, Rhss: []TokenType{TkVar, TkSws, TkComma, TkVar, TkSws, TkDeclare, TkSws}},
+		{Lhs: TkDecl, Rhss: []TokenType{TkVar, TkSws, TkComma, TkVar, TkSws, TkDeclare}},
+		{Lhs: TkDecl, Rhss: []TokenType{TkVar, TkSws, TkComma, TkVar, TkDeclare, TkSws}},
+		{Lhs: TkDecl, Rhss: []TokenType{TkVar, TkSws, TkComma, TkVar, TkDeclare}},
+		{Lhs: TkDecl, Rhss: []TokenType{TkVar, TkSws, TkDeclare, TkSws}},
+		{Lhs: TkDecl, Rhss: []TokenType{TkVar, TkSws, TkDeclare}},
+		{Lhs: TkDecl, Rhss: []TokenType{TkVar, TkComma, TkSws, TkVar, TkSws, TkDeclare, TkSws}},
+		{Lhs: TkDecl, Rhss: []TokenType{TkVar, TkComma, TkSws, TkVar, TkSws, TkDeclare}},
+		{Lhs: TkDecl, Rhss: []TokenType{TkVar, TkComma, TkSws, TkVar, TkDeclare, TkSws}},
+		{Lhs: TkDecl, Rhss: []TokenType{TkVar, TkComma, TkSws, TkVar, TkDeclare}},
+		{Lhs: TkDecl, Rhss: []TokenType{TkVar, TkComma, TkVar, TkSws, TkDeclare, TkSws}},
+		{Lhs: TkDecl, Rhss: []TokenType{TkVar, TkComma, TkVar, TkSws, TkDeclare}},
+		{Lhs: TkDecl, Rhss: []TokenType{TkVar, TkComma, TkVar, TkDeclare, TkSws}},
+		{Lhs: TkDecl, Rhss: []TokenType{TkVar, TkComma, TkVar, TkDeclare}},
+		{Lhs: TkDecl, Rhss: []TokenType{TkVar, TkDeclare, TkSws}},
+		{Lhs: TkDecl, Rhss: []TokenType{TkVar, TkDeclare}},
+		{Lhs: TkEnd, Rhss: []TokenType{TkKwEnd, TkSws}},
+		{Lhs: TkEnd, Rhss: []TokenType{TkKwEnd}},
+		{Lhs: TkNote, Rhss: []TokenType{TkComment, TkSws}},
+		{Lhs: TkNote, Rhss: []TokenType{TkComment}},
+		{Lhs: TkDefine, Rhss: []TokenType{TkKwDefine, TkSws, TkString, TkSws}},
+		{Lhs: TkDefine, Rhss: []TokenType{TkKwDefine, TkSws, TkString}},
+		{Lhs: TkInclude, Rhss: []TokenType{TkKwTemplate, TkSws, TkString, TkSws, TkPipeline}},
+		{Lhs: TkInclude, Rhss: []TokenType{TkKwTemplate, TkSws, TkString, TkSws}},
+		{Lhs: TkInclude, Rhss: []TokenType{TkKwTemplate, TkSws, TkString}},
+		{Lhs: TkBlock, Rhss: []TokenType{TkKwBlock, TkSws, TkString, TkSws, TkPipeline}},
+		{Lhs: TkPipeline, Rhss: []TokenType{TkCall}},
+		{Lhs: TkPipeline, Rhss: []TokenType{TkCall, TkPipeline1}},
+		{Lhs: TkPipeline1, Rhss: []TokenType{TkPipe, TkSws, TkCall}},
+		{Lhs: TkPipeline1, Rhss: []TokenType{TkPipe, TkCall}},
+		{Lhs: TkPipeline1, Rhss: []TokenType{TkPipeline1, TkPipe, TkSws, TkCall}},
+		{Lhs: TkPipeline1, Rhss: []TokenType{TkPipeline1, TkPipe, TkCall}},
+		{Lhs: TkCall, Rhss: []TokenType{TkOperand}},
+		{Lhs: TkCall, Rhss: []TokenType{TkOperand, TkCall1}},
+		{Lhs: TkCall1, Rhss: []TokenType{TkOperand}},
+		{Lhs: TkCall1, Rhss: []TokenType{TkCall1, TkOperand}},
+		{Lhs: TkOperand, Rhss: []TokenType{TkField}},
+		{Lhs: TkOperand, Rhss: []TokenType{TkLiteral}},
+		{Lhs: TkOperand, Rhss: []TokenType{TkFunc}},
+		{Lhs: TkField, Rhss: []TokenType{TkPath, TkSws}},
+		{Lhs: TkField, Rhss: []TokenType{TkPath}},
+		{Lhs: TkField, Rhss: []TokenType{TkVar, TkSws}},
+		{Lhs: TkField, Rhss: []TokenType{TkVar}},
+		{Lhs: TkFunc, Rhss: []TokenType{TkIdent, TkSws}},
+		{Lhs: TkFunc, Rhss: []TokenType{TkIdent}},
+		{Lhs: TkLiteral, Rhss: []TokenType{TkString, TkSws}},
+		{Lhs: TkLiteral, Rhss: []TokenType{TkString}},
+		{Lhs: TkLiteral, Rhss: []TokenType{TkNumber, TkSws}},
+		{Lhs: TkLiteral, Rhss: []TokenType{TkNumber}},
+		{Lhs: TkLiteral, Rhss: []TokenType{TkKwTrue, TkSws}},
+		{Lhs: TkLiteral, Rhss: []TokenType{TkKwTrue}},
+		{Lhs: TkLiteral, Rhss: []TokenType{TkKwFalse, TkSws}},
+		{Lhs: TkLiteral, Rhss: []TokenType{TkKwFalse}},
+		{Lhs: TkSws, Rhss: []TokenType{TkWs}},
+		{Lhs: TkSws, Rhss: []TokenType{TkWs, TkSws1}},
+		{Lhs: TkSws1, Rhss: []TokenType{TkWs}},
+		{Lhs: TkSws1, Rhss: []TokenType{TkSws1, TkWs}},
+	},
+	Helpers: []TokenType{TkSource1, TkPipeline1, TkCall1, TkSws1},
+	States: []utpx.StaticState[TokenType]{
+		{ // State 0
+			Gotos:   map[TokenType]int{TkText: 1, TkOpCurly: 2, TkOpTrim: 3, TkElem: 4, TkAction: 5, TkOpen: 6},
+			Actions: map[TokenType]utpx.StaticAction{TkText: {Kind: utpx.StaticShift}, TkOpCurly: {Kind: utpx.StaticShift}, TkOpTrim: {Kind: utpx.StaticShift}},
+		},
+		{ // State 1
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkEOF: {Kind: utpx.StaticReduce, Rule: 5}, TkText: {Kind: utpx.StaticReduce, Rule: 5}, TkOpCurly: {Kind: utpx.StaticReduce, Rule: 5}, TkOpTrim: {Kind: utpx.StaticReduce, Rule: 5}},
+		},
+		{ // State 2
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkVar: {Kind: utpx.StaticReduce, Rule: 8}, TkKwIf: {Kind: utpx.StaticReduce, Rule: 8}, TkKwElse: {Kind: utpx.StaticReduce, Rule: 8}, TkKwRange: {Kind: utpx.StaticReduce, Rule: 8}, TkKwEnd: {Kind: utpx.StaticReduce, Rule: 8}, TkComment: {Kind: utpx.StaticReduce, Rule: 8}, TkKwDefine: {Kind: utpx.StaticReduce, Rule: 8}, TkString: {Kind: utpx.StaticReduce, Rule: 8}, TkKwTemplate: {Kind: utpx.StaticReduce, Rule: 8}, TkKwBlock: {Kind: utpx.StaticReduce, Rule: 8}, TkPath: {Kind: utpx.StaticReduce, Rule: 8}, TkIdent: {Kind: utpx.StaticReduce, Rule: 8}, TkNumber: {Kind: utpx.StaticReduce, Rule: 8}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 8}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 8}, TkWs: {Kind: utpx.StaticReduce, Rule: 8}},
+		},
+		{ // State 3
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkVar: {Kind: utpx.StaticReduce, Rule: 9}, TkKwIf: {Kind: utpx.StaticReduce, Rule: 9}, TkKwElse: {Kind: utpx.StaticReduce, Rule: 9}, TkKwRange: {Kind: utpx.StaticReduce, Rule: 9}, TkKwEnd: {Kind: utpx.StaticReduce, Rule: 9}, TkComment: {Kind: utpx.StaticReduce, Rule: 9}, TkKwDefine: {Kind: utpx.StaticReduce, Rule: 9}, TkString: {Kind: utpx.StaticReduce, Rule: 9}, TkKwTemplate: {Kind: utpx.StaticReduce, Rule: 9}, TkKwBlock: {Kind: utpx.StaticReduce, Rule: 9}, TkPath: {Kind: utpx.StaticReduce, Rule: 9}, TkIdent: {Kind: utpx.StaticReduce, Rule: 9}, TkNumber: {Kind: utpx.StaticReduce, Rule: 9}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 9}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 9}, TkWs: {Kind: utpx.StaticReduce, Rule: 9}},
+		},
+		{ // State 4
+			Gotos:   map[TokenType]int{TkEOF: 7, TkText: 1, TkOpCurly: 2, TkOpTrim: 3, TkElem: 8, TkSource1: 9, TkAction: 5, TkOpen: 6},
+			Actions: map[TokenType]utpx.StaticAction{TkEOF: {Kind: utpx.StaticShift}, TkText: {Kind: utpx.StaticShift}, TkOpCurly: {Kind: utpx.StaticShift}, TkOpTrim: {Kind: utpx.StaticShift}},
+		},
+		{ // State 5
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkEOF: {Kind: utpx.StaticReduce, Rule: 4}, TkText: {Kind: utpx.StaticReduce, Rule: 4}, TkOpCurly: {Kind: utpx.StaticReduce, Rule: 4}, TkOpTrim: {Kind: utpx.StaticReduce, Rule: 4}},
+		},
+		{ // State 6
+			Gotos:   map[TokenType]int{TkVar: 10, TkKwIf: 11, TkKwElse: 12, TkKwRange: 13, TkKwEnd: 14, TkComment: 15, TkKwDefine: 16, TkString: 17, TkKwTemplate: 18, TkKwBlock: 19, TkPath: 20, TkIdent: 21, TkNumber: 22, TkKwTrue: 23, TkKwFalse: 24, TkWs: 25, TkSws: 26, TkCommand: 27, TkVariable: 28, TkAssign: 29, TkIf: 30, TkElseIf: 31, TkElse: 32, TkRange: 33, TkEnd: 34, TkNote: 35, TkDefine: 36, TkInclude: 37, TkBlock: 38, TkPipeline: 39, TkCall: 40, TkOperand: 41, TkField: 42, TkLiteral: 43, TkFunc: 44},
+			Actions: map[TokenType]utpx.StaticAction{TkVar: {Kind: utpx.StaticShift}, TkKwIf: {Kind: utpx.StaticShift}, TkKwElse: {Kind: utpx.StaticShift}, TkKwRange: {Kind: utpx.StaticShift}, TkKwEnd: {Kind: utpx.StaticShift}, TkComment: {Kind: utpx.StaticShift}, TkKwDefine: {Kind: utpx.StaticShift}, TkString: {Kind: utpx.StaticShift}, TkKwTemplate: {Kind: utpx.StaticShift}, TkKwBlock: {Kind: utpx.StaticShift}, TkPath: {Kind: utpx.StaticShift}, TkIdent: {Kind: utpx.StaticShift}, TkNumber: {Kind: utpx.StaticShift}, TkKwTrue: {Kind: utpx.StaticShift}, TkKwFalse: {Kind: utpx.StaticShift}, TkWs: {Kind: utpx.StaticShift}},
+		},
+		{ // State 7
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{},
+			End:     &utpx.StaticAction{Kind: utpx.StaticAccept, Rule: 0},
+		},
+		{ // State 8
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkEOF: {Kind: utpx.StaticReduce, Rule: 2}, TkText: {Kind: utpx.StaticReduce, Rule: 2}, TkOpCurly: {Kind: utpx.StaticReduce, Rule: 2}, TkOpTrim: {Kind: utpx.StaticReduce, Rule: 2}},
+		},
+		{ // State 9
+			Gotos:   map[TokenType]int{TkEOF: 45, TkText: 1, TkOpCurly: 2, TkOpTrim: 3, TkElem: 46, TkAction: 5, TkOpen: 6},
+			Actions: map[TokenType]utpx.StaticAction{TkEOF: {Kind: utpx.StaticShift}, TkText: {Kind: utpx.StaticShift}, TkOpCurly: {Kind: utpx.StaticShift}, TkOpTrim: {Kind: utpx.StaticShift}},
+		},
+		{ // State 10
+			Gotos:   map[TokenType]int{TkDeclare: 47, TkWs: 48, TkSws: 49},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 80}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 80}, TkVar: {Kind: utpx.StaticReduce, Rule: 80}, TkDeclare: {Kind: utpx.StaticShift}, TkString: {Kind: utpx.StaticReduce, Rule: 80}, TkPipe: {Kind: utpx.StaticReduce, Rule: 80}, TkPath: {Kind: utpx.StaticReduce, Rule: 80}, TkIdent: {Kind: utpx.StaticReduce, Rule: 80}, TkNumber: {Kind: utpx.StaticReduce, Rule: 80}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 80}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 80}, TkWs: {Kind: utpx.StaticShift}},
+		},
+		{ // State 11
+			Gotos:   map[TokenType]int{TkWs: 50, TkSws: 51},
+			Actions: map[TokenType]utpx.StaticAction{TkWs: {Kind: utpx.StaticShift}},
+		},
+		{ // State 12
+			Gotos:   map[TokenType]int{TkWs: 52, TkSws: 53},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 31}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 31}, TkWs: {Kind: utpx.StaticShift}},
+		},
+		{ // State 13
+			Gotos:   map[TokenType]int{TkWs: 50, TkSws: 54},
+			Actions: map[TokenType]utpx.StaticAction{TkWs: {Kind: utpx.StaticShift}},
+		},
+		{ // State 14
+			Gotos:   map[TokenType]int{TkWs: 55, TkSws: 56},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 55}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 55}, TkWs: {Kind: utpx.StaticShift}},
+		},
+		{ // State 15
+			Gotos:   map[TokenType]int{TkWs: 55, TkSws: 57},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 57}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 57}, TkWs: {Kind: utpx.StaticShift}},
+		},
+		{ // State 16
+			Gotos:   map[TokenType]int{TkWs: 58, TkSws: 59},
+			Actions: map[TokenType]utpx.StaticAction{TkWs: {Kind: utpx.StaticShift}},
+		},
+		{ // State 17
+			Gotos:   map[TokenType]int{TkWs: 60, TkSws: 61},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 84}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 84}, TkVar: {Kind: utpx.StaticReduce, Rule: 84}, TkString: {Kind: utpx.StaticReduce, Rule: 84}, TkPipe: {Kind: utpx.StaticReduce, Rule: 84}, TkPath: {Kind: utpx.StaticReduce, Rule: 84}, TkIdent: {Kind: utpx.StaticReduce, Rule: 84}, TkNumber: {Kind: utpx.StaticReduce, Rule: 84}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 84}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 84}, TkWs: {Kind: utpx.StaticShift}},
+		},
+		{ // State 18
+			Gotos:   map[TokenType]int{TkWs: 58, TkSws: 62},
+			Actions: map[TokenType]utpx.StaticAction{TkWs: {Kind: utpx.StaticShift}},
+		},
+		{ // State 19
+			Gotos:   map[TokenType]int{TkWs: 58, TkSws: 63},
+			Actions: map[TokenType]utpx.StaticAction{TkWs: {Kind: utpx.StaticShift}},
+		},
+		{ // State 20
+			Gotos:   map[TokenType]int{TkWs: 60, TkSws: 64},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 78}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 78}, TkVar: {Kind: utpx.StaticReduce, Rule: 78}, TkString: {Kind: utpx.StaticReduce, Rule: 78}, TkPipe: {Kind: utpx.StaticReduce, Rule: 78}, TkPath: {Kind: utpx.StaticReduce, Rule: 78}, TkIdent: {Kind: utpx.StaticReduce, Rule: 78}, TkNumber: {Kind: utpx.StaticReduce, Rule: 78}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 78}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 78}, TkWs: {Kind: utpx.StaticShift}},
+		},
+		{ // State 21
+			Gotos:   map[TokenType]int{TkWs: 60, TkSws: 65},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 82}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 82}, TkVar: {Kind: utpx.StaticReduce, Rule: 82}, TkString: {Kind: utpx.StaticReduce, Rule: 82}, TkPipe: {Kind: utpx.StaticReduce, Rule: 82}, TkPath: {Kind: utpx.StaticReduce, Rule: 82}, TkIdent: {Kind: utpx.StaticReduce, Rule: 82}, TkNumber: {Kind: utpx.StaticReduce, Rule: 82}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 82}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 82}, TkWs: {Kind: utpx.StaticShift}},
+		},
+		{ // State 22
+			Gotos:   map[TokenType]int{TkWs: 60, TkSws: 66},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 86}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 86}, TkVar: {Kind: utpx.StaticReduce, Rule: 86}, TkString: {Kind: utpx.StaticReduce, Rule: 86}, TkPipe: {Kind: utpx.StaticReduce, Rule: 86}, TkPath: {Kind: utpx.StaticReduce, Rule: 86}, TkIdent: {Kind: utpx.StaticReduce, Rule: 86}, TkNumber: {Kind: utpx.StaticReduce, Rule: 86}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 86}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 86}, TkWs: {Kind: utpx.StaticShift}},
+		},
+		{ // State 23
+			Gotos:   map[TokenType]int{TkWs: 60, TkSws: 67},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 88}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 88}, TkVar: {Kind: utpx.StaticReduce, Rule: 88}, TkString: {Kind: utpx.StaticReduce, Rule: 88}, TkPipe: {Kind: utpx.StaticReduce, Rule: 88}, TkPath: {Kind: utpx.StaticReduce, Rule: 88}, TkIdent: {Kind: utpx.StaticReduce, Rule: 88}, TkNumber: {Kind: utpx.StaticReduce, Rule: 88}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 88}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 88}, TkWs: {Kind: utpx.StaticShift}},
+		},
+		{ // State 24
+			Gotos:   map[TokenType]int{TkWs: 60, TkSws: 68},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 90}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 90}, TkVar: {Kind: utpx.StaticReduce, Rule: 90}, TkString: {Kind: utpx.StaticReduce, Rule: 90}, TkPipe: {Kind: utpx.StaticReduce, Rule: 90}, TkPath: {Kind: utpx.StaticReduce, Rule: 90}, TkIdent: {Kind: utpx.StaticReduce, Rule: 90}, TkNumber: {Kind: utpx.StaticReduce, Rule: 90}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 90}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 90}, TkWs: {Kind: utpx.StaticShift}},
+		},
+		{ // State 25
+			Gotos:   map[TokenType]int{TkWs: 69, TkSws1: 70},
+			Actions: map[TokenType]utpx.StaticAction{TkVar: {Kind: utpx.StaticReduce, Rule: 91}, TkKwIf: {Kind: utpx.StaticReduce, Rule: 91}, TkKwElse: {Kind: utpx.StaticReduce, Rule: 91}, TkKwRange: {Kind: utpx.StaticReduce, Rule: 91}, TkKwEnd: {Kind: utpx.StaticReduce, Rule: 91}, TkComment: {Kind: utpx.StaticReduce, Rule: 91}, TkKwDefine: {Kind: utpx.StaticReduce, Rule: 91}, TkString: {Kind: utpx.StaticReduce, Rule: 91}, TkKwTemplate: {Kind: utpx.StaticReduce, Rule: 91}, TkKwBlock: {Kind: utpx.StaticReduce, Rule: 91}, TkPath: {Kind: utpx.StaticReduce, Rule: 91}, TkIdent: {Kind: utpx.StaticReduce, Rule: 91}, TkNumber: {Kind: utpx.StaticReduce, Rule: 91}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 91}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 91}, TkWs: {Kind: utpx.StaticShift}},
+		},
+		{ // State 26
+			Gotos:   map[TokenType]int{TkVar: 10, TkKwIf: 11, TkKwElse: 12, TkKwRange: 13, TkKwEnd: 14, TkComment: 15, TkKwDefine: 16, TkString: 17, TkKwTemplate: 18, TkKwBlock: 19, TkPath: 20, TkIdent: 21, TkNumber: 22, TkKwTrue: 23, TkKwFalse: 24, TkCommand: 71, TkVariable: 28, TkAssign: 29, TkIf: 30, TkElseIf: 31, TkElse: 32, TkRange: 33, TkEnd: 34, TkNote: 35, TkDefine: 36, TkInclude: 37, TkBlock: 38, TkPipeline: 39, TkCall: 40, TkOperand: 41, TkField: 42, TkLiteral: 43, TkFunc: 44},
+			Actions: map[TokenType]utpx.StaticAction{TkVar: {Kind: utpx.StaticShift}, TkKwIf: {Kind: utpx.StaticShift}, TkKwElse: {Kind: utpx.StaticShift}, TkKwRange: {Kind: utpx.StaticShift}, TkKwEnd: {Kind: utpx.StaticShift}, TkComment: {Kind: utpx.StaticShift}, TkKwDefine: {Kind: utpx.StaticShift}, TkString: {Kind: utpx.StaticShift}, TkKwTemplate: {Kind: utpx.StaticShift}, TkKwBlock: {Kind: utpx.StaticShift}, TkPath: {Kind: utpx.StaticShift}, TkIdent: {Kind: utpx.StaticShift}, TkNumber: {Kind: utpx.StaticShift}, TkKwTrue: {Kind: utpx.StaticShift}, TkKwFalse: {Kind: utpx.StaticShift}},
+		},
+		{ // State 27
+			Gotos:   map[TokenType]int{TkClCurly: 72, TkClTrim: 73, TkClose: 74},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticShift}, TkClTrim: {Kind: utpx.StaticShift}},
+		},
+		{ // State 28
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 12}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 12}},
+		},
+		{ // State 29
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 13}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 13}},
+		},
+		{ // State 30
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 14}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 14}},
+		},
+		{ // State 31
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 15}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 15}},
+		},
+		{ // State 32
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 16}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 16}},
+		},
+		{ // State 33
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 17}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 17}},
+		},
+		{ // State 34
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 18}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 18}},
+		},
+		{ // State 35
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 19}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 19}},
+		},
+		{ // State 36
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 20}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 20}},
+		},
+		{ // State 37
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 21}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 21}},
+		},
+		{ // State 38
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 22}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 22}},
+		},
+		{ // State 39
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 23}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 23}},
+		},
+		{ // State 40
+			Gotos:   map[TokenType]int{TkPipe: 75, TkPipeline1: 76},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 64}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 64}, TkPipe: {Kind: utpx.StaticShift}},
+		},
+		{ // State 41
+			Gotos:   map[TokenType]int{TkVar: 77, TkString: 17, TkPath: 20, TkIdent: 21, TkNumber: 22, TkKwTrue: 23, TkKwFalse: 24, TkOperand: 78, TkCall1: 79, TkField: 42, TkLiteral: 43, TkFunc: 44},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 70}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 70}, TkVar: {Kind: utpx.StaticShift}, TkString: {Kind: utpx.StaticShift}, TkPipe: {Kind: utpx.StaticReduce, Rule: 70}, TkPath: {Kind: utpx.StaticShift}, TkIdent: {Kind: utpx.StaticShift}, TkNumber: {Kind: utpx.StaticShift}, TkKwTrue: {Kind: utpx.StaticShift}, TkKwFalse: {Kind: utpx.StaticShift}},
+		},
+		{ // State 42
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 74}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 74}, TkVar: {Kind: utpx.StaticReduce, Rule: 74}, TkString: {Kind: utpx.StaticReduce, Rule: 74}, TkPipe: {Kind: utpx.StaticReduce, Rule: 74}, TkPath: {Kind: utpx.StaticReduce, Rule: 74}, TkIdent: {Kind: utpx.StaticReduce, Rule: 74}, TkNumber: {Kind: utpx.StaticReduce, Rule: 74}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 74}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 74}},
+		},
+		{ // State 43
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 75}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 75}, TkVar: {Kind: utpx.StaticReduce, Rule: 75}, TkString: {Kind: utpx.StaticReduce, Rule: 75}, TkPipe: {Kind: utpx.StaticReduce, Rule: 75}, TkPath: {Kind: utpx.StaticReduce, Rule: 75}, TkIdent: {Kind: utpx.StaticReduce, Rule: 75}, TkNumber: {Kind: utpx.StaticReduce, Rule: 75}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 75}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 75}},
+		},
+		{ // State 44
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 76}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 76}, TkVar: {Kind: utpx.StaticReduce, Rule: 76}, TkString: {Kind: utpx.StaticReduce, Rule: 76}, TkPipe: {Kind: utpx.StaticReduce, Rule: 76}, TkPath: {Kind: utpx.StaticReduce, Rule: 76}, TkIdent: {Kind: utpx.StaticReduce, Rule: 76}, TkNumber: {Kind: utpx.StaticReduce, Rule: 76}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 76}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 76}},
+		},
+		{ // State 45
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{},
+			End:     &utpx.StaticAction{Kind: utpx.StaticAccept, Rule: 1},
+		},
+		{ // State 46
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkEOF: {Kind: utpx.StaticReduce, Rule: 3}, TkText: {Kind: utpx.StaticReduce, Rule: 3}, TkOpCurly: {Kind: utpx.StaticReduce, Rule: 3}, TkOpTrim: {Kind: utpx.StaticReduce, Rule: 3}},
+		},
+		{ // State 47
+			Gotos:   map[TokenType]int{TkVar: 77, TkString: 17, TkPath: 20, TkIdent: 21, TkNumber: 22, TkKwTrue: 23, TkKwFalse: 24, TkWs: 50, TkSws: 80, TkPipeline: 81, TkCall: 40, TkOperand: 41, TkField: 42, TkLiteral: 43, TkFunc: 44},
+			Actions: map[TokenType]utpx.StaticAction{TkVar: {Kind: utpx.StaticShift}, TkString: {Kind: utpx.StaticShift}, TkPath: {Kind: utpx.StaticShift}, TkIdent: {Kind: utpx.StaticShift}, TkNumber: {Kind: utpx.StaticShift}, TkKwTrue: {Kind: utpx.StaticShift}, TkKwFalse: {Kind: utpx.StaticShift}, TkWs: {Kind: utpx.StaticShift}},
+		},
+		{ // State 48
+			Gotos:   map[TokenType]int{TkWs: 82, TkSws1: 83},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 91}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 91}, TkVar: {Kind: utpx.StaticReduce, Rule: 91}, TkDeclare: {Kind: utpx.StaticReduce, Rule: 91}, TkString: {Kind: utpx.StaticReduce, Rule: 91}, TkPipe: {Kind: utpx.StaticReduce, Rule: 91}, TkPath: {Kind: utpx.StaticReduce, Rule: 91}, TkIdent: {Kind: utpx.StaticReduce, Rule: 91}, TkNumber: {Kind: utpx.StaticReduce, Rule: 91}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 91}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 91}, TkWs: {Kind: utpx.StaticShift}},
+		},
+		{ // State 49
+			Gotos:   map[TokenType]int{TkDeclare: 84},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 79}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 79}, TkVar: {Kind: utpx.StaticReduce, Rule: 79}, TkDeclare: {Kind: utpx.StaticShift}, TkString: {Kind: utpx.StaticReduce, Rule: 79}, TkPipe: {Kind: utpx.StaticReduce, Rule: 79}, TkPath: {Kind: utpx.StaticReduce, Rule: 79}, TkIdent: {Kind: utpx.StaticReduce, Rule: 79}, TkNumber: {Kind: utpx.StaticReduce, Rule: 79}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 79}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 79}},
+		},
+		{ // State 50
+			Gotos:   map[TokenType]int{TkWs: 85, TkSws1: 86},
+			Actions: map[TokenType]utpx.StaticAction{TkVar: {Kind: utpx.StaticReduce, Rule: 91}, TkString: {Kind: utpx.StaticReduce, Rule: 91}, TkPath: {Kind: utpx.StaticReduce, Rule: 91}, TkIdent: {Kind: utpx.StaticReduce, Rule: 91}, TkNumber: {Kind: utpx.StaticReduce, Rule: 91}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 91}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 91}, TkWs: {Kind: utpx.StaticShift}},
+		},
+		{ // State 51
+			Gotos:   map[TokenType]int{TkVar: 77, TkString: 17, TkPath: 20, TkIdent: 21, TkNumber: 22, TkKwTrue: 23, TkKwFalse: 24, TkPipeline: 87, TkCall: 40, TkOperand: 41, TkField: 42, TkLiteral: 43, TkFunc: 44},
+			Actions: map[TokenType]utpx.StaticAction{TkVar: {Kind: utpx.StaticShift}, TkString: {Kind: utpx.StaticShift}, TkPath: {Kind: utpx.StaticShift}, TkIdent: {Kind: utpx.StaticShift}, TkNumber: {Kind: utpx.StaticShift}, TkKwTrue: {Kind: utpx.StaticShift}, TkKwFalse: {Kind: utpx.StaticShift}},
+		},
+		{ // State 52
+			Gotos:   map[TokenType]int{TkWs: 88, TkSws1: 89},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 91}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 91}, TkKwIf: {Kind: utpx.StaticReduce, Rule: 91}, TkWs: {Kind: utpx.StaticShift}},
+		},
+		{ // State 53
+			Gotos:   map[TokenType]int{TkKwIf: 90},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 30}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 30}, TkKwIf: {Kind: utpx.StaticShift}},
+		},
+		{ // State 54
+			Gotos:   map[TokenType]int{TkVar: 91, TkString: 17, TkPath: 20, TkIdent: 21, TkNumber: 22, TkKwTrue: 23, TkKwFalse: 24, TkPipeline: 92, TkDecl: 93, TkCall: 40, TkOperand: 41, TkField: 42, TkLiteral: 43, TkFunc: 44},
+			Actions: map[TokenType]utpx.StaticAction{TkVar: {Kind: utpx.StaticShift}, TkString: {Kind: utpx.StaticShift}, TkPath: {Kind: utpx.StaticShift}, TkIdent: {Kind: utpx.StaticShift}, TkNumber: {Kind: utpx.StaticShift}, TkKwTrue: {Kind: utpx.StaticShift}, TkKwFalse: {Kind: utpx.StaticShift}},
+		},
+		{ // State 55
+			Gotos:   map[TokenType]int{TkWs: 94, TkSws1: 95},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 91}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 91}, TkWs: {Kind: utpx.StaticShift}},
+		},
+		{ // State 56
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 54}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 54}},
+		},
+		{ // State 57
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 56}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 56}},
+		},
+		{ // State 58
+			Gotos:   map[TokenType]int{TkWs: 96, TkSws1: 97},
+			Actions: map[TokenType]utpx.StaticAction{TkString: {Kind: utpx.StaticReduce, Rule: 91}, TkWs: {Kind: utpx.StaticShift}},
+		},
+		{ // State 59
+			Gotos:   map[TokenType]int{TkString: 98},
+			Actions: map[TokenType]utpx.StaticAction{TkString: {Kind: utpx.StaticShift}},
+		},
+		{ // State 60
+			Gotos:   map[TokenType]int{TkWs: 99, TkSws1: 100},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 91}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 91}, TkVar: {Kind: utpx.StaticReduce, Rule: 91}, TkString: {Kind: utpx.StaticReduce, Rule: 91}, TkPipe: {Kind: utpx.StaticReduce, Rule: 91}, TkPath: {Kind: utpx.StaticReduce, Rule: 91}, TkIdent: {Kind: utpx.StaticReduce, Rule: 91}, TkNumber: {Kind: utpx.StaticReduce, Rule: 91}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 91}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 91}, TkWs: {Kind: utpx.StaticShift}},
+		},
+		{ // State 61
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 83}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 83}, TkVar: {Kind: utpx.StaticReduce, Rule: 83}, TkString: {Kind: utpx.StaticReduce, Rule: 83}, TkPipe: {Kind: utpx.StaticReduce, Rule: 83}, TkPath: {Kind: utpx.StaticReduce, Rule: 83}, TkIdent: {Kind: utpx.StaticReduce, Rule: 83}, TkNumber: {Kind: utpx.StaticReduce, Rule: 83}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 83}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 83}},
+		},
+		{ // State 62
+			Gotos:   map[TokenType]int{TkString: 101},
+			Actions: map[TokenType]utpx.StaticAction{TkString: {Kind: utpx.StaticShift}},
+		},
+		{ // State 63
+			Gotos:   map[TokenType]int{TkString: 102},
+			Actions: map[TokenType]utpx.StaticAction{TkString: {Kind: utpx.StaticShift}},
+		},
+		{ // State 64
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 77}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 77}, TkVar: {Kind: utpx.StaticReduce, Rule: 77}, TkString: {Kind: utpx.StaticReduce, Rule: 77}, TkPipe: {Kind: utpx.StaticReduce, Rule: 77}, TkPath: {Kind: utpx.StaticReduce, Rule: 77}, TkIdent: {Kind: utpx.StaticReduce, Rule: 77}, TkNumber: {Kind: utpx.StaticReduce, Rule: 77}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 77}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 77}},
+		},
+		{ // State 65
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 81}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 81}, TkVar: {Kind: utpx.StaticReduce, Rule: 81}, TkString: {Kind: utpx.StaticReduce, Rule: 81}, TkPipe: {Kind: utpx.StaticReduce, Rule: 81}, TkPath: {Kind: utpx.StaticReduce, Rule: 81}, TkIdent: {Kind: utpx.StaticReduce, Rule: 81}, TkNumber: {Kind: utpx.StaticReduce, Rule: 81}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 81}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 81}},
+		},
+		{ // State 66
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 85}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 85}, TkVar: {Kind: utpx.StaticReduce, Rule: 85}, TkString: {Kind: utpx.StaticReduce, Rule: 85}, TkPipe: {Kind: utpx.StaticReduce, Rule: 85}, TkPath: {Kind: utpx.StaticReduce, Rule: 85}, TkIdent: {Kind: utpx.StaticReduce, Rule: 85}, TkNumber: {Kind: utpx.StaticReduce, Rule: 85}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 85}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 85}},
+		},
+		{ // State 67
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 87}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 87}, TkVar: {Kind: utpx.StaticReduce, Rule: 87}, TkString: {Kind: utpx.StaticReduce, Rule: 87}, TkPipe: {Kind: utpx.StaticReduce, Rule: 87}, TkPath: {Kind: utpx.StaticReduce, Rule: 87}, TkIdent: {Kind: utpx.StaticReduce, Rule: 87}, TkNumber: {Kind: utpx.StaticReduce, Rule: 87}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 87}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 87}},
+		},
+		{ // State 68
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 89}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 89}, TkVar: {Kind: utpx.StaticReduce, Rule: 89}, TkString: {Kind: utpx.StaticReduce, Rule: 89}, TkPipe: {Kind: utpx.StaticReduce, Rule: 89}, TkPath: {Kind: utpx.StaticReduce, Rule: 89}, TkIdent: {Kind: utpx.StaticReduce, Rule: 89}, TkNumber: {Kind: utpx.StaticReduce, Rule: 89}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 89}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 89}},
+		},
+		{ // State 69
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkVar: {Kind: utpx.StaticReduce, Rule: 93}, TkKwIf: {Kind: utpx.StaticReduce, Rule: 93}, TkKwElse: {Kind: utpx.StaticReduce, Rule: 93}, TkKwRange: {Kind: utpx.StaticReduce, Rule: 93}, TkKwEnd: {Kind: utpx.StaticReduce, Rule: 93}, TkComment: {Kind: utpx.StaticReduce, Rule: 93}, TkKwDefine: {Kind: utpx.StaticReduce, Rule: 93}, TkString: {Kind: utpx.StaticReduce, Rule: 93}, TkKwTemplate: {Kind: utpx.StaticReduce, Rule: 93}, TkKwBlock: {Kind: utpx.StaticReduce, Rule: 93}, TkPath: {Kind: utpx.StaticReduce, Rule: 93}, TkIdent: {Kind: utpx.StaticReduce, Rule: 93}, TkNumber: {Kind: utpx.StaticReduce, Rule: 93}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 93}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 93}, TkWs: {Kind: utpx.StaticReduce, Rule: 93}},
+		},
+		{ // State 70
+			Gotos:   map[TokenType]int{TkWs: 103},
+			Actions: map[TokenType]utpx.StaticAction{TkVar: {Kind: utpx.StaticReduce, Rule: 92}, TkKwIf: {Kind: utpx.StaticReduce, Rule: 92}, TkKwElse: {Kind: utpx.StaticReduce, Rule: 92}, TkKwRange: {Kind: utpx.StaticReduce, Rule: 92}, TkKwEnd: {Kind: utpx.StaticReduce, Rule: 92}, TkComment: {Kind: utpx.StaticReduce, Rule: 92}, TkKwDefine: {Kind: utpx.StaticReduce, Rule: 92}, TkString: {Kind: utpx.StaticReduce, Rule: 92}, TkKwTemplate: {Kind: utpx.StaticReduce, Rule: 92}, TkKwBlock: {Kind: utpx.StaticReduce, Rule: 92}, TkPath: {Kind: utpx.StaticReduce, Rule: 92}, TkIdent: {Kind: utpx.StaticReduce, Rule: 92}, TkNumber: {Kind: utpx.StaticReduce, Rule: 92}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 92}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 92}, TkWs: {Kind: utpx.StaticShift}},
+		},
+		{ // State 71
+			Gotos:   map[TokenType]int{TkClCurly: 72, TkClTrim: 73, TkClose: 104},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticShift}, TkClTrim: {Kind: utpx.StaticShift}},
+		},
+		{ // State 72
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkEOF: {Kind: utpx.StaticReduce, Rule: 10}, TkText: {Kind: utpx.StaticReduce, Rule: 10}, TkOpCurly: {Kind: utpx.StaticReduce, Rule: 10}, TkOpTrim: {Kind: utpx.StaticReduce, Rule: 10}},
+		},
+		{ // State 73
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkEOF: {Kind: utpx.StaticReduce, Rule: 11}, TkText: {Kind: utpx.StaticReduce, Rule: 11}, TkOpCurly: {Kind: utpx.StaticReduce, Rule: 11}, TkOpTrim: {Kind: utpx.StaticReduce, Rule: 11}},
+		},
+		{ // State 74
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkEOF: {Kind: utpx.StaticReduce, Rule: 7}, TkText: {Kind: utpx.StaticReduce, Rule: 7}, TkOpCurly: {Kind: utpx.StaticReduce, Rule: 7}, TkOpTrim: {Kind: utpx.StaticReduce, Rule: 7}},
+		},
+		{ // State 75
+			Gotos:   map[TokenType]int{TkVar: 77, TkString: 17, TkPath: 20, TkIdent: 21, TkNumber: 22, TkKwTrue: 23, TkKwFalse: 24, TkWs: 50, TkSws: 105, TkCall: 106, TkOperand: 41, TkField: 42, TkLiteral: 43, TkFunc: 44},
+			Actions: map[TokenType]utpx.StaticAction{TkVar: {Kind: utpx.StaticShift}, TkString: {Kind: utpx.StaticShift}, TkPath: {Kind: utpx.StaticShift}, TkIdent: {Kind: utpx.StaticShift}, TkNumber: {Kind: utpx.StaticShift}, TkKwTrue: {Kind: utpx.StaticShift}, TkKwFalse: {Kind: utpx.StaticShift}, TkWs: {Kind: utpx.StaticShift}},
+		},
+		{ // State 76
+			Gotos:   map[TokenType]int{TkPipe: 107},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 65}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 65}, TkPipe: {Kind: utpx.StaticShift}},
+		},
+		{ // State 77
+			Gotos:   map[TokenType]int{TkWs: 60, TkSws: 108},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 80}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 80}, TkVar: {Kind: utpx.StaticReduce, Rule: 80}, TkString: {Kind: utpx.StaticReduce, Rule: 80}, TkPipe: {Kind: utpx.StaticReduce, Rule: 80}, TkPath: {Kind: utpx.StaticReduce, Rule: 80}, TkIdent: {Kind: utpx.StaticReduce, Rule: 80}, TkNumber: {Kind: utpx.StaticReduce, Rule: 80}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 80}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 80}, TkWs: {Kind: utpx.StaticShift}},
+		},
+		{ // State 78
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 72}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 72}, TkVar: {Kind: utpx.StaticReduce, Rule: 72}, TkString: {Kind: utpx.StaticReduce, Rule: 72}, TkPipe: {Kind: utpx.StaticReduce, Rule: 72}, TkPath: {Kind: utpx.StaticReduce, Rule: 72}, TkIdent: {Kind: utpx.StaticReduce, Rule: 72}, TkNumber: {Kind: utpx.StaticReduce, Rule: 72}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 72}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 72}},
+		},
+		{ // State 79
+			Gotos:   map[TokenType]int{TkVar: 77, TkString: 17, TkPath: 20, TkIdent: 21, TkNumber: 22, TkKwTrue: 23, TkKwFalse: 24, TkOperand: 109, TkField: 42, TkLiteral: 43, TkFunc: 44},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 71}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 71}, TkVar: {Kind: utpx.StaticShift}, TkString: {Kind: utpx.StaticShift}, TkPipe: {Kind: utpx.StaticReduce, Rule: 71}, TkPath: {Kind: utpx.StaticShift}, TkIdent: {Kind: utpx.StaticShift}, TkNumber: {Kind: utpx.StaticShift}, TkKwTrue: {Kind: utpx.StaticShift}, TkKwFalse: {Kind: utpx.StaticShift}},
+		},
+		{ // State 80
+			Gotos:   map[TokenType]int{TkVar: 77, TkString: 17, TkPath: 20, TkIdent: 21, TkNumber: 22, TkKwTrue: 23, TkKwFalse: 24, TkPipeline: 110, TkCall: 40, TkOperand: 41, TkField: 42, TkLiteral: 43, TkFunc: 44},
+			Actions: map[TokenType]utpx.StaticAction{TkVar: {Kind: utpx.StaticShift}, TkString: {Kind: utpx.StaticShift}, TkPath: {Kind: utpx.StaticShift}, TkIdent: {Kind: utpx.StaticShift}, TkNumber: {Kind: utpx.StaticShift}, TkKwTrue: {Kind: utpx.StaticShift}, TkKwFalse: {Kind: utpx.StaticShift}},
+		},
+		{ // State 81
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 27}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 27}},
+		},
+		{ // State 82
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 93}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 93}, TkVar: {Kind: utpx.StaticReduce, Rule: 93}, TkDeclare: {Kind: utpx.StaticReduce, Rule: 93}, TkString: {Kind: utpx.StaticReduce, Rule: 93}, TkPipe: {Kind: utpx.StaticReduce, Rule: 93}, TkPath: {Kind: utpx.StaticReduce, Rule: 93}, TkIdent: {Kind: utpx.StaticReduce, Rule: 93}, TkNumber: {Kind: utpx.StaticReduce, Rule: 93}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 93}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 93}, TkWs: {Kind: utpx.StaticReduce, Rule: 93}},
+		},
+		{ // State 83
+			Gotos:   map[TokenType]int{TkWs: 111},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 92}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 92}, TkVar: {Kind: utpx.StaticReduce, Rule: 92}, TkDeclare: {Kind: utpx.StaticReduce, Rule: 92}, TkString: {Kind: utpx.StaticReduce, Rule: 92}, TkPipe: {Kind: utpx.StaticReduce, Rule: 92}, TkPath: {Kind: utpx.StaticReduce, Rule: 92}, TkIdent: {Kind: utpx.StaticReduce, Rule: 92}, TkNumber: {Kind: utpx.StaticReduce, Rule: 92}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 92}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 92}, TkWs: {Kind: utpx.StaticShift}},
+		},
+		{ // State 84
+			Gotos:   map[TokenType]int{TkVar: 77, TkString: 17, TkPath: 20, TkIdent: 21, TkNumber: 22, TkKwTrue: 23, TkKwFalse: 24, TkWs: 50, TkSws: 112, TkPipeline: 113, TkCall: 40, TkOperand: 41, TkField: 42, TkLiteral: 43, TkFunc: 44},
+			Actions: map[TokenType]utpx.StaticAction{TkVar: {Kind: utpx.StaticShift}, TkString: {Kind: utpx.StaticShift}, TkPath: {Kind: utpx.StaticShift}, TkIdent: {Kind: utpx.StaticShift}, TkNumber: {Kind: utpx.StaticShift}, TkKwTrue: {Kind: utpx.StaticShift}, TkKwFalse: {Kind: utpx.StaticShift}, TkWs: {Kind: utpx.StaticShift}},
+		},
+		{ // State 85
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkVar: {Kind: utpx.StaticReduce, Rule: 93}, TkString: {Kind: utpx.StaticReduce, Rule: 93}, TkPath: {Kind: utpx.StaticReduce, Rule: 93}, TkIdent: {Kind: utpx.StaticReduce, Rule: 93}, TkNumber: {Kind: utpx.StaticReduce, Rule: 93}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 93}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 93}, TkWs: {Kind: utpx.StaticReduce, Rule: 93}},
+		},
+		{ // State 86
+			Gotos:   map[TokenType]int{TkWs: 114},
+			Actions: map[TokenType]utpx.StaticAction{TkVar: {Kind: utpx.StaticReduce, Rule: 92}, TkString: {Kind: utpx.StaticReduce, Rule: 92}, TkPath: {Kind: utpx.StaticReduce, Rule: 92}, TkIdent: {Kind: utpx.StaticReduce, Rule: 92}, TkNumber: {Kind: utpx.StaticReduce, Rule: 92}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 92}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 92}, TkWs: {Kind: utpx.StaticShift}},
+		},
+		{ // State 87
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 28}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 28}},
+		},
+		{ // State 88
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 93}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 93}, TkKwIf: {Kind: utpx.StaticReduce, Rule: 93}, TkWs: {Kind: utpx.StaticReduce, Rule: 93}},
+		},
+		{ // State 89
+			Gotos:   map[TokenType]int{TkWs: 115},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 92}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 92}, TkKwIf: {Kind: utpx.StaticReduce, Rule: 92}, TkWs: {Kind: utpx.StaticShift}},
+		},
+		{ // State 90
+			Gotos:   map[TokenType]int{TkWs: 50, TkSws: 116},
+			Actions: map[TokenType]utpx.StaticAction{TkWs: {Kind: utpx.StaticShift}},
+		},
+		{ // State 91
+			Gotos:   map[TokenType]int{TkDeclare: 117, TkComma: 118, TkWs: 119, TkSws: 120},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 80}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 80}, TkVar: {Kind: utpx.StaticReduce, Rule: 80}, TkDeclare: {Kind: utpx.StaticShift}, TkComma: {Kind: utpx.StaticShift}, TkString: {Kind: utpx.StaticReduce, Rule: 80}, TkPipe: {Kind: utpx.StaticReduce, Rule: 80}, TkPath: {Kind: utpx.StaticReduce, Rule: 80}, TkIdent: {Kind: utpx.StaticReduce, Rule: 80}, TkNumber: {Kind: utpx.StaticReduce, Rule: 80}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 80}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 80}, TkWs: {Kind: utpx.StaticShift}},
+		},
+		{ // State 92
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 33}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 33}},
+		},
+		{ // State 93
+			Gotos:   map[TokenType]int{TkVar: 77, TkString: 17, TkPath: 20, TkIdent: 21, TkNumber: 22, TkKwTrue: 23, TkKwFalse: 24, TkPipeline: 121, TkCall: 40, TkOperand: 41, TkField: 42, TkLiteral: 43, TkFunc: 44},
+			Actions: map[TokenType]utpx.StaticAction{TkVar: {Kind: utpx.StaticShift}, TkString: {Kind: utpx.StaticShift}, TkPath: {Kind: utpx.StaticShift}, TkIdent: {Kind: utpx.StaticShift}, TkNumber: {Kind: utpx.StaticShift}, TkKwTrue: {Kind: utpx.StaticShift}, TkKwFalse: {Kind: utpx.StaticShift}},
+		},
+		{ // State 94
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 93}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 93}, TkWs: {Kind: utpx.StaticReduce, Rule: 93}},
+		},
+		{ // State 95
+			Gotos:   map[TokenType]int{TkWs: 122},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 92}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 92}, TkWs: {Kind: utpx.StaticShift}},
+		},
+		{ // State 96
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkString: {Kind: utpx.StaticReduce, Rule: 93}, TkWs: {Kind: utpx.StaticReduce, Rule: 93}},
+		},
+		{ // State 97
+			Gotos:   map[TokenType]int{TkWs: 123},
+			Actions: map[TokenType]utpx.StaticAction{TkString: {Kind: utpx.StaticReduce, Rule: 92}, TkWs: {Kind: utpx.StaticShift}},
+		},
+		{ // State 98
+			Gotos:   map[TokenType]int{TkWs: 55, TkSws: 124},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 59}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 59}, TkWs: {Kind: utpx.StaticShift}},
+		},
+		{ // State 99
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 93}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 93}, TkVar: {Kind: utpx.StaticReduce, Rule: 93}, TkString: {Kind: utpx.StaticReduce, Rule: 93}, TkPipe: {Kind: utpx.StaticReduce, Rule: 93}, TkPath: {Kind: utpx.StaticReduce, Rule: 93}, TkIdent: {Kind: utpx.StaticReduce, Rule: 93}, TkNumber: {Kind: utpx.StaticReduce, Rule: 93}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 93}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 93}, TkWs: {Kind: utpx.StaticReduce, Rule: 93}},
+		},
+		{ // State 100
+			Gotos:   map[TokenType]int{TkWs: 125},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 92}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 92}, TkVar: {Kind: utpx.StaticReduce, Rule: 92}, TkString: {Kind: utpx.StaticReduce, Rule: 92}, TkPipe: {Kind: utpx.StaticReduce, Rule: 92}, TkPath: {Kind: utpx.StaticReduce, Rule: 92}, TkIdent: {Kind: utpx.StaticReduce, Rule: 92}, TkNumber: {Kind: utpx.StaticReduce, Rule: 92}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 92}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 92}, TkWs: {Kind: utpx.StaticShift}},
+		},
+		{ // State 101
+			Gotos:   map[TokenType]int{TkWs: 126, TkSws: 127},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 62}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 62}, TkWs: {Kind: utpx.StaticShift}},
+		},
+		{ // State 102
+			Gotos:   map[TokenType]int{TkWs: 50, TkSws: 128},
+			Actions: map[TokenType]utpx.StaticAction{TkWs: {Kind: utpx.StaticShift}},
+		},
+		{ // State 103
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkVar: {Kind: utpx.StaticReduce, Rule: 94}, TkKwIf: {Kind: utpx.StaticReduce, Rule: 94}, TkKwElse: {Kind: utpx.StaticReduce, Rule: 94}, TkKwRange: {Kind: utpx.StaticReduce, Rule: 94}, TkKwEnd: {Kind: utpx.StaticReduce, Rule: 94}, TkComment: {Kind: utpx.StaticReduce, Rule: 94}, TkKwDefine: {Kind: utpx.StaticReduce, Rule: 94}, TkString: {Kind: utpx.StaticReduce, Rule: 94}, TkKwTemplate: {Kind: utpx.StaticReduce, Rule: 94}, TkKwBlock: {Kind: utpx.StaticReduce, Rule: 94}, TkPath: {Kind: utpx.StaticReduce, Rule: 94}, TkIdent: {Kind: utpx.StaticReduce, Rule: 94}, TkNumber: {Kind: utpx.StaticReduce, Rule: 94}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 94}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 94}, TkWs: {Kind: utpx.StaticReduce, Rule: 94}},
+		},
+		{ // State 104
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkEOF: {Kind: utpx.StaticReduce, Rule: 6}, TkText: {Kind: utpx.StaticReduce, Rule: 6}, TkOpCurly: {Kind: utpx.StaticReduce, Rule: 6}, TkOpTrim: {Kind: utpx.StaticReduce, Rule: 6}},
+		},
+		{ // State 105
+			Gotos:   map[TokenType]int{TkVar: 77, TkString: 17, TkPath: 20, TkIdent: 21, TkNumber: 22, TkKwTrue: 23, TkKwFalse: 24, TkCall: 129, TkOperand: 41, TkField: 42, TkLiteral: 43, TkFunc: 44},
+			Actions: map[TokenType]utpx.StaticAction{TkVar: {Kind: utpx.StaticShift}, TkString: {Kind: utpx.StaticShift}, TkPath: {Kind: utpx.StaticShift}, TkIdent: {Kind: utpx.StaticShift}, TkNumber: {Kind: utpx.StaticShift}, TkKwTrue: {Kind: utpx.StaticShift}, TkKwFalse: {Kind: utpx.StaticShift}},
+		},
+		{ // State 106
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 67}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 67}, TkPipe: {Kind: utpx.StaticReduce, Rule: 67}},
+		},
+		{ // State 107
+			Gotos:   map[TokenType]int{TkVar: 77, TkString: 17, TkPath: 20, TkIdent: 21, TkNumber: 22, TkKwTrue: 23, TkKwFalse: 24, TkWs: 50, TkSws: 130, TkCall: 131, TkOperand: 41, TkField: 42, TkLiteral: 43, TkFunc: 44},
+			Actions: map[TokenType]utpx.StaticAction{TkVar: {Kind: utpx.StaticShift}, TkString: {Kind: utpx.StaticShift}, TkPath: {Kind: utpx.StaticShift}, TkIdent: {Kind: utpx.StaticShift}, TkNumber: {Kind: utpx.StaticShift}, TkKwTrue: {Kind: utpx.StaticShift}, TkKwFalse: {Kind: utpx.StaticShift}, TkWs: {Kind: utpx.StaticShift}},
+		},
+		{ // State 108
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 79}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 79}, TkVar: {Kind: utpx.StaticReduce, Rule: 79}, TkString: {Kind: utpx.StaticReduce, Rule: 79}, TkPipe: {Kind: utpx.StaticReduce, Rule: 79}, TkPath: {Kind: utpx.StaticReduce, Rule: 79}, TkIdent: {Kind: utpx.StaticReduce, Rule: 79}, TkNumber: {Kind: utpx.StaticReduce, Rule: 79}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 79}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 79}},
+		},
+		{ // State 109
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 73}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 73}, TkVar: {Kind: utpx.StaticReduce, Rule: 73}, TkString: {Kind: utpx.StaticReduce, Rule: 73}, TkPipe: {Kind: utpx.StaticReduce, Rule: 73}, TkPath: {Kind: utpx.StaticReduce, Rule: 73}, TkIdent: {Kind: utpx.StaticReduce, Rule: 73}, TkNumber: {Kind: utpx.StaticReduce, Rule: 73}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 73}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 73}},
+		},
+		{ // State 110
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 26}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 26}},
+		},
+		{ // State 111
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 94}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 94}, TkVar: {Kind: utpx.StaticReduce, Rule: 94}, TkDeclare: {Kind: utpx.StaticReduce, Rule: 94}, TkString: {Kind: utpx.StaticReduce, Rule: 94}, TkPipe: {Kind: utpx.StaticReduce, Rule: 94}, TkPath: {Kind: utpx.StaticReduce, Rule: 94}, TkIdent: {Kind: utpx.StaticReduce, Rule: 94}, TkNumber: {Kind: utpx.StaticReduce, Rule: 94}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 94}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 94}, TkWs: {Kind: utpx.StaticReduce, Rule: 94}},
+		},
+		{ // State 112
+			Gotos:   map[TokenType]int{TkVar: 77, TkString: 17, TkPath: 20, TkIdent: 21, TkNumber: 22, TkKwTrue: 23, TkKwFalse: 24, TkPipeline: 132, TkCall: 40, TkOperand: 41, TkField: 42, TkLiteral: 43, TkFunc: 44},
+			Actions: map[TokenType]utpx.StaticAction{TkVar: {Kind: utpx.StaticShift}, TkString: {Kind: utpx.StaticShift}, TkPath: {Kind: utpx.StaticShift}, TkIdent: {Kind: utpx.StaticShift}, TkNumber: {Kind: utpx.StaticShift}, TkKwTrue: {Kind: utpx.StaticShift}, TkKwFalse: {Kind: utpx.StaticShift}},
+		},
+		{ // State 113
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 25}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 25}},
+		},
+		{ // State 114
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkVar: {Kind: utpx.StaticReduce, Rule: 94}, TkString: {Kind: utpx.StaticReduce, Rule: 94}, TkPath: {Kind: utpx.StaticReduce, Rule: 94}, TkIdent: {Kind: utpx.StaticReduce, Rule: 94}, TkNumber: {Kind: utpx.StaticReduce, Rule: 94}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 94}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 94}, TkWs: {Kind: utpx.StaticReduce, Rule: 94}},
+		},
+		{ // State 115
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 94}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 94}, TkKwIf: {Kind: utpx.StaticReduce, Rule: 94}, TkWs: {Kind: utpx.StaticReduce, Rule: 94}},
+		},
+		{ // State 116
+			Gotos:   map[TokenType]int{TkVar: 77, TkString: 17, TkPath: 20, TkIdent: 21, TkNumber: 22, TkKwTrue: 23, TkKwFalse: 24, TkPipeline: 133, TkCall: 40, TkOperand: 41, TkField: 42, TkLiteral: 43, TkFunc: 44},
+			Actions: map[TokenType]utpx.StaticAction{TkVar: {Kind: utpx.StaticShift}, TkString: {Kind: utpx.StaticShift}, TkPath: {Kind: utpx.StaticShift}, TkIdent: {Kind: utpx.StaticShift}, TkNumber: {Kind: utpx.StaticShift}, TkKwTrue: {Kind: utpx.StaticShift}, TkKwFalse: {Kind: utpx.StaticShift}},
+		},
+		{ // State 117
+			Gotos:   map[TokenType]int{TkWs: 50, TkSws: 134},
+			Actions: map[TokenType]utpx.StaticAction{TkVar: {Kind: utpx.StaticReduce, Rule: 53}, TkString: {Kind: utpx.StaticReduce, Rule: 53}, TkPath: {Kind: utpx.StaticReduce, Rule: 53}, TkIdent: {Kind: utpx.StaticReduce, Rule: 53}, TkNumber: {Kind: utpx.StaticReduce, Rule: 53}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 53}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 53}, TkWs: {Kind: utpx.StaticShift}},
+		},
+		{ // State 118
+			Gotos:   map[TokenType]int{TkVar: 135, TkWs: 136, TkSws: 137},
+			Actions: map[TokenType]utpx.StaticAction{TkVar: {Kind: utpx.StaticShift}, TkWs: {Kind: utpx.StaticShift}},
+		},
+		{ // State 119
+			Gotos:   map[TokenType]int{TkWs: 138, TkSws1: 139},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 91}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 91}, TkVar: {Kind: utpx.StaticReduce, Rule: 91}, TkDeclare: {Kind: utpx.StaticReduce, Rule: 91}, TkComma: {Kind: utpx.StaticReduce, Rule: 91}, TkString: {Kind: utpx.StaticReduce, Rule: 91}, TkPipe: {Kind: utpx.StaticReduce, Rule: 91}, TkPath: {Kind: utpx.StaticReduce, Rule: 91}, TkIdent: {Kind: utpx.StaticReduce, Rule: 91}, TkNumber: {Kind: utpx.StaticReduce, Rule: 91}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 91}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 91}, TkWs: {Kind: utpx.StaticShift}},
+		},
+		{ // State 120
+			Gotos:   map[TokenType]int{TkDeclare: 140, TkComma: 141},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 79}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 79}, TkVar: {Kind: utpx.StaticReduce, Rule: 79}, TkDeclare: {Kind: utpx.StaticShift}, TkComma: {Kind: utpx.StaticShift}, TkString: {Kind: utpx.StaticReduce, Rule: 79}, TkPipe: {Kind: utpx.StaticReduce, Rule: 79}, TkPath: {Kind: utpx.StaticReduce, Rule: 79}, TkIdent: {Kind: utpx.StaticReduce, Rule: 79}, TkNumber: {Kind: utpx.StaticReduce, Rule: 79}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 79}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 79}},
+		},
+		{ // State 121
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 32}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 32}},
+		},
+		{ // State 122
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 94}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 94}, TkWs: {Kind: utpx.StaticReduce, Rule: 94}},
+		},
+		{ // State 123
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkString: {Kind: utpx.StaticReduce, Rule: 94}, TkWs: {Kind: utpx.StaticReduce, Rule: 94}},
+		},
+		{ // State 124
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 58}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 58}},
+		},
+		{ // State 125
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 94}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 94}, TkVar: {Kind: utpx.StaticReduce, Rule: 94}, TkString: {Kind: utpx.StaticReduce, Rule: 94}, TkPipe: {Kind: utpx.StaticReduce, Rule: 94}, TkPath: {Kind: utpx.StaticReduce, Rule: 94}, TkIdent: {Kind: utpx.StaticReduce, Rule: 94}, TkNumber: {Kind: utpx.StaticReduce, Rule: 94}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 94}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 94}, TkWs: {Kind: utpx.StaticReduce, Rule: 94}},
+		},
+		{ // State 126
+			Gotos:   map[TokenType]int{TkWs: 142, TkSws1: 143},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 91}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 91}, TkVar: {Kind: utpx.StaticReduce, Rule: 91}, TkString: {Kind: utpx.StaticReduce, Rule: 91}, TkPath: {Kind: utpx.StaticReduce, Rule: 91}, TkIdent: {Kind: utpx.StaticReduce, Rule: 91}, TkNumber: {Kind: utpx.StaticReduce, Rule: 91}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 91}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 91}, TkWs: {Kind: utpx.StaticShift}},
+		},
+		{ // State 127
+			Gotos:   map[TokenType]int{TkVar: 77, TkString: 17, TkPath: 20, TkIdent: 21, TkNumber: 22, TkKwTrue: 23, TkKwFalse: 24, TkPipeline: 144, TkCall: 40, TkOperand: 41, TkField: 42, TkLiteral: 43, TkFunc: 44},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 61}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 61}, TkVar: {Kind: utpx.StaticShift}, TkString: {Kind: utpx.StaticShift}, TkPath: {Kind: utpx.StaticShift}, TkIdent: {Kind: utpx.StaticShift}, TkNumber: {Kind: utpx.StaticShift}, TkKwTrue: {Kind: utpx.StaticShift}, TkKwFalse: {Kind: utpx.StaticShift}},
+		},
+		{ // State 128
+			Gotos:   map[TokenType]int{TkVar: 77, TkString: 17, TkPath: 20, TkIdent: 21, TkNumber: 22, TkKwTrue: 23, TkKwFalse: 24, TkPipeline: 145, TkCall: 40, TkOperand: 41, TkField: 42, TkLiteral: 43, TkFunc: 44},
+			Actions: map[TokenType]utpx.StaticAction{TkVar: {Kind: utpx.StaticShift}, TkString: {Kind: utpx.StaticShift}, TkPath: {Kind: utpx.StaticShift}, TkIdent: {Kind: utpx.StaticShift}, TkNumber: {Kind: utpx.StaticShift}, TkKwTrue: {Kind: utpx.StaticShift}, TkKwFalse: {Kind: utpx.StaticShift}},
+		},
+		{ // State 129
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 66}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 66}, TkPipe: {Kind: utpx.StaticReduce, Rule: 66}},
+		},
+		{ // State 130
+			Gotos:   map[TokenType]int{TkVar: 77, TkString: 17, TkPath: 20, TkIdent: 21, TkNumber: 22, TkKwTrue: 23, TkKwFalse: 24, TkCall: 146, TkOperand: 41, TkField: 42, TkLiteral: 43, TkFunc: 44},
+			Actions: map[TokenType]utpx.StaticAction{TkVar: {Kind: utpx.StaticShift}, TkString: {Kind: utpx.StaticShift}, TkPath: {Kind: utpx.StaticShift}, TkIdent: {Kind: utpx.StaticShift}, TkNumber: {Kind: utpx.StaticShift}, TkKwTrue: {Kind: utpx.StaticShift}, TkKwFalse: {Kind: utpx.StaticShift}},
+		},
+		{ // State 131
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 69}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 69}, TkPipe: {Kind: utpx.StaticReduce, Rule: 69}},
+		},
+		{ // State 132
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 24}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 24}},
+		},
+		{ // State 133
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 29}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 29}},
+		},
+		{ // State 134
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkVar: {Kind: utpx.StaticReduce, Rule: 52}, TkString: {Kind: utpx.StaticReduce, Rule: 52}, TkPath: {Kind: utpx.StaticReduce, Rule: 52}, TkIdent: {Kind: utpx.StaticReduce, Rule: 52}, TkNumber: {Kind: utpx.StaticReduce, Rule: 52}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 52}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 52}},
+		},
+		{ // State 135
+			Gotos:   map[TokenType]int{TkDeclare: 147, TkWs: 148, TkSws: 149},
+			Actions: map[TokenType]utpx.StaticAction{TkDeclare: {Kind: utpx.StaticShift}, TkWs: {Kind: utpx.StaticShift}},
+		},
+		{ // State 136
+			Gotos:   map[TokenType]int{TkWs: 150, TkSws1: 151},
+			Actions: map[TokenType]utpx.StaticAction{TkVar: {Kind: utpx.StaticReduce, Rule: 91}, TkWs: {Kind: utpx.StaticShift}},
+		},
+		{ // State 137
+			Gotos:   map[TokenType]int{TkVar: 152},
+			Actions: map[TokenType]utpx.StaticAction{TkVar: {Kind: utpx.StaticShift}},
+		},
+		{ // State 138
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 93}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 93}, TkVar: {Kind: utpx.StaticReduce, Rule: 93}, TkDeclare: {Kind: utpx.StaticReduce, Rule: 93}, TkComma: {Kind: utpx.StaticReduce, Rule: 93}, TkString: {Kind: utpx.StaticReduce, Rule: 93}, TkPipe: {Kind: utpx.StaticReduce, Rule: 93}, TkPath: {Kind: utpx.StaticReduce, Rule: 93}, TkIdent: {Kind: utpx.StaticReduce, Rule: 93}, TkNumber: {Kind: utpx.StaticReduce, Rule: 93}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 93}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 93}, TkWs: {Kind: utpx.StaticReduce, Rule: 93}},
+		},
+		{ // State 139
+			Gotos:   map[TokenType]int{TkWs: 153},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 92}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 92}, TkVar: {Kind: utpx.StaticReduce, Rule: 92}, TkDeclare: {Kind: utpx.StaticReduce, Rule: 92}, TkComma: {Kind: utpx.StaticReduce, Rule: 92}, TkString: {Kind: utpx.StaticReduce, Rule: 92}, TkPipe: {Kind: utpx.StaticReduce, Rule: 92}, TkPath: {Kind: utpx.StaticReduce, Rule: 92}, TkIdent: {Kind: utpx.StaticReduce, Rule: 92}, TkNumber: {Kind: utpx.StaticReduce, Rule: 92}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 92}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 92}, TkWs: {Kind: utpx.StaticShift}},
+		},
+		{ // State 140
+			Gotos:   map[TokenType]int{TkWs: 50, TkSws: 154},
+			Actions: map[TokenType]utpx.StaticAction{TkVar: {Kind: utpx.StaticReduce, Rule: 43}, TkString: {Kind: utpx.StaticReduce, Rule: 43}, TkPath: {Kind: utpx.StaticReduce, Rule: 43}, TkIdent: {Kind: utpx.StaticReduce, Rule: 43}, TkNumber: {Kind: utpx.StaticReduce, Rule: 43}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 43}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 43}, TkWs: {Kind: utpx.StaticShift}},
+		},
+		{ // State 141
+			Gotos:   map[TokenType]int{TkVar: 155, TkWs: 136, TkSws: 156},
+			Actions: map[TokenType]utpx.StaticAction{TkVar: {Kind: utpx.StaticShift}, TkWs: {Kind: utpx.StaticShift}},
+		},
+		{ // State 142
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 93}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 93}, TkVar: {Kind: utpx.StaticReduce, Rule: 93}, TkString: {Kind: utpx.StaticReduce, Rule: 93}, TkPath: {Kind: utpx.StaticReduce, Rule: 93}, TkIdent: {Kind: utpx.StaticReduce, Rule: 93}, TkNumber: {Kind: utpx.StaticReduce, Rule: 93}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 93}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 93}, TkWs: {Kind: utpx.StaticReduce, Rule: 93}},
+		},
+		{ // State 143
+			Gotos:   map[TokenType]int{TkWs: 157},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 92}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 92}, TkVar: {Kind: utpx.StaticReduce, Rule: 92}, TkString: {Kind: utpx.StaticReduce, Rule: 92}, TkPath: {Kind: utpx.StaticReduce, Rule: 92}, TkIdent: {Kind: utpx.StaticReduce, Rule: 92}, TkNumber: {Kind: utpx.StaticReduce, Rule: 92}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 92}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 92}, TkWs: {Kind: utpx.StaticShift}},
+		},
+		{ // State 144
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 60}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 60}},
+		},
+		{ // State 145
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 63}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 63}},
+		},
+		{ // State 146
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 68}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 68}, TkPipe: {Kind: utpx.StaticReduce, Rule: 68}},
+		},
+		{ // State 147
+			Gotos:   map[TokenType]int{TkWs: 50, TkSws: 158},
+			Actions: map[TokenType]utpx.StaticAction{TkVar: {Kind: utpx.StaticReduce, Rule: 51}, TkString: {Kind: utpx.StaticReduce, Rule: 51}, TkPath: {Kind: utpx.StaticReduce, Rule: 51}, TkIdent: {Kind: utpx.StaticReduce, Rule: 51}, TkNumber: {Kind: utpx.StaticReduce, Rule: 51}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 51}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 51}, TkWs: {Kind: utpx.StaticShift}},
+		},
+		{ // State 148
+			Gotos:   map[TokenType]int{TkWs: 159, TkSws1: 160},
+			Actions: map[TokenType]utpx.StaticAction{TkDeclare: {Kind: utpx.StaticReduce, Rule: 91}, TkWs: {Kind: utpx.StaticShift}},
+		},
+		{ // State 149
+			Gotos:   map[TokenType]int{TkDeclare: 161},
+			Actions: map[TokenType]utpx.StaticAction{TkDeclare: {Kind: utpx.StaticShift}},
+		},
+		{ // State 150
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkVar: {Kind: utpx.StaticReduce, Rule: 93}, TkWs: {Kind: utpx.StaticReduce, Rule: 93}},
+		},
+		{ // State 151
+			Gotos:   map[TokenType]int{TkWs: 162},
+			Actions: map[TokenType]utpx.StaticAction{TkVar: {Kind: utpx.StaticReduce, Rule: 92}, TkWs: {Kind: utpx.StaticShift}},
+		},
+		{ // State 152
+			Gotos:   map[TokenType]int{TkDeclare: 163, TkWs: 148, TkSws: 164},
+			Actions: map[TokenType]utpx.StaticAction{TkDeclare: {Kind: utpx.StaticShift}, TkWs: {Kind: utpx.StaticShift}},
+		},
+		{ // State 153
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 94}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 94}, TkVar: {Kind: utpx.StaticReduce, Rule: 94}, TkDeclare: {Kind: utpx.StaticReduce, Rule: 94}, TkComma: {Kind: utpx.StaticReduce, Rule: 94}, TkString: {Kind: utpx.StaticReduce, Rule: 94}, TkPipe: {Kind: utpx.StaticReduce, Rule: 94}, TkPath: {Kind: utpx.StaticReduce, Rule: 94}, TkIdent: {Kind: utpx.StaticReduce, Rule: 94}, TkNumber: {Kind: utpx.StaticReduce, Rule: 94}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 94}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 94}, TkWs: {Kind: utpx.StaticReduce, Rule: 94}},
+		},
+		{ // State 154
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkVar: {Kind: utpx.StaticReduce, Rule: 42}, TkString: {Kind: utpx.StaticReduce, Rule: 42}, TkPath: {Kind: utpx.StaticReduce, Rule: 42}, TkIdent: {Kind: utpx.StaticReduce, Rule: 42}, TkNumber: {Kind: utpx.StaticReduce, Rule: 42}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 42}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 42}},
+		},
+		{ // State 155
+			Gotos:   map[TokenType]int{TkDeclare: 165, TkWs: 148, TkSws: 166},
+			Actions: map[TokenType]utpx.StaticAction{TkDeclare: {Kind: utpx.StaticShift}, TkWs: {Kind: utpx.StaticShift}},
+		},
+		{ // State 156
+			Gotos:   map[TokenType]int{TkVar: 167},
+			Actions: map[TokenType]utpx.StaticAction{TkVar: {Kind: utpx.StaticShift}},
+		},
+		{ // State 157
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkClCurly: {Kind: utpx.StaticReduce, Rule: 94}, TkClTrim: {Kind: utpx.StaticReduce, Rule: 94}, TkVar: {Kind: utpx.StaticReduce, Rule: 94}, TkString: {Kind: utpx.StaticReduce, Rule: 94}, TkPath: {Kind: utpx.StaticReduce, Rule: 94}, TkIdent: {Kind: utpx.StaticReduce, Rule: 94}, TkNumber: {Kind: utpx.StaticReduce, Rule: 94}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 94}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 94}, TkWs: {Kind: utpx.StaticReduce, Rule: 94}},
+		},
+		{ // State 158
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkVar: {Kind: utpx.StaticReduce, Rule: 50}, TkString: {Kind: utpx.StaticReduce, Rule: 50}, TkPath: {Kind: utpx.StaticReduce, Rule: 50}, TkIdent: {Kind: utpx.StaticReduce, Rule: 50}, TkNumber: {Kind: utpx.StaticReduce, Rule: 50}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 50}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 50}},
+		},
+		{ // State 159
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkDeclare: {Kind: utpx.StaticReduce, Rule: 93}, TkWs: {Kind: utpx.StaticReduce, Rule: 93}},
+		},
+		{ // State 160
+			Gotos:   map[TokenType]int{TkWs: 168},
+			Actions: map[TokenType]utpx.StaticAction{TkDeclare: {Kind: utpx.StaticReduce, Rule: 92}, TkWs: {Kind: utpx.StaticShift}},
+		},
+		{ // State 161
+			Gotos:   map[TokenType]int{TkWs: 50, TkSws: 169},
+			Actions: map[TokenType]utpx.StaticAction{TkVar: {Kind: utpx.StaticReduce, Rule: 49}, TkString: {Kind: utpx.StaticReduce, Rule: 49}, TkPath: {Kind: utpx.StaticReduce, Rule: 49}, TkIdent: {Kind: utpx.StaticReduce, Rule: 49}, TkNumber: {Kind: utpx.StaticReduce, Rule: 49}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 49}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 49}, TkWs: {Kind: utpx.StaticShift}},
+		},
+		{ // State 162
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkVar: {Kind: utpx.StaticReduce, Rule: 94}, TkWs: {Kind: utpx.StaticReduce, Rule: 94}},
+		},
+		{ // State 163
+			Gotos:   map[TokenType]int{TkWs: 50, TkSws: 170},
+			Actions: map[TokenType]utpx.StaticAction{TkVar: {Kind: utpx.StaticReduce, Rule: 47}, TkString: {Kind: utpx.StaticReduce, Rule: 47}, TkPath: {Kind: utpx.StaticReduce, Rule: 47}, TkIdent: {Kind: utpx.StaticReduce, Rule: 47}, TkNumber: {Kind: utpx.StaticReduce, Rule: 47}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 47}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 47}, TkWs: {Kind: utpx.StaticShift}},
+		},
+		{ // State 164
+			Gotos:   map[TokenType]int{TkDeclare: 171},
+			Actions: map[TokenType]utpx.StaticAction{TkDeclare: {Kind: utpx.StaticShift}},
+		},
+		{ // State 165
+			Gotos:   map[TokenType]int{TkWs: 50, TkSws: 172},
+			Actions: map[TokenType]utpx.StaticAction{TkVar: {Kind: utpx.StaticReduce, Rule: 41}, TkString: {Kind: utpx.StaticReduce, Rule: 41}, TkPath: {Kind: utpx.StaticReduce, Rule: 41}, TkIdent: {Kind: utpx.StaticReduce, Rule: 41}, TkNumber: {Kind: utpx.StaticReduce, Rule: 41}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 41}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 41}, TkWs: {Kind: utpx.StaticShift}},
+		},
+		{ // State 166
+			Gotos:   map[TokenType]int{TkDeclare: 173},
+			Actions: map[TokenType]utpx.StaticAction{TkDeclare: {Kind: utpx.StaticShift}},
+		},
+		{ // State 167
+			Gotos:   map[TokenType]int{TkDeclare: 174, TkWs: 148, TkSws: 175},
+			Actions: map[TokenType]utpx.StaticAction{TkDeclare: {Kind: utpx.StaticShift}, TkWs: {Kind: utpx.StaticShift}},
+		},
+		{ // State 168
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkDeclare: {Kind: utpx.StaticReduce, Rule: 94}, TkWs: {Kind: utpx.StaticReduce, Rule: 94}},
+		},
+		{ // State 169
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkVar: {Kind: utpx.StaticReduce, Rule: 48}, TkString: {Kind: utpx.StaticReduce, Rule: 48}, TkPath: {Kind: utpx.StaticReduce, Rule: 48}, TkIdent: {Kind: utpx.StaticReduce, Rule: 48}, TkNumber: {Kind: utpx.StaticReduce, Rule: 48}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 48}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 48}},
+		},
+		{ // State 170
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkVar: {Kind: utpx.StaticReduce, Rule: 46}, TkString: {Kind: utpx.StaticReduce, Rule: 46}, TkPath: {Kind: utpx.StaticReduce, Rule: 46}, TkIdent: {Kind: utpx.StaticReduce, Rule: 46}, TkNumber: {Kind: utpx.StaticReduce, Rule: 46}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 46}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 46}},
+		},
+		{ // State 171
+			Gotos:   map[TokenType]int{TkWs: 50, TkSws: 176},
+			Actions: map[TokenType]utpx.StaticAction{TkVar: {Kind: utpx.StaticReduce, Rule: 45}, TkString: {Kind: utpx.StaticReduce, Rule: 45}, TkPath: {Kind: utpx.StaticReduce, Rule: 45}, TkIdent: {Kind: utpx.StaticReduce, Rule: 45}, TkNumber: {Kind: utpx.StaticReduce, Rule: 45}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 45}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 45}, TkWs: {Kind: utpx.StaticShift}},
+		},
+		{ // State 172
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkVar: {Kind: utpx.StaticReduce, Rule: 40}, TkString: {Kind: utpx.StaticReduce, Rule: 40}, TkPath: {Kind: utpx.StaticReduce, Rule: 40}, TkIdent: {Kind: utpx.StaticReduce, Rule: 40}, TkNumber: {Kind: utpx.StaticReduce, Rule: 40}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 40}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 40}},
+		},
+		{ // State 173
+			Gotos:   map[TokenType]int{TkWs: 50, TkSws: 177},
+			Actions: map[TokenType]utpx.StaticAction{TkVar: {Kind: utpx.StaticReduce, Rule: 39}, TkString: {Kind: utpx.StaticReduce, Rule: 39}, TkPath: {Kind: utpx.StaticReduce, Rule: 39}, TkIdent: {Kind: utpx.StaticReduce, Rule: 39}, TkNumber: {Kind: utpx.StaticReduce, Rule: 39}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 39}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 39}, TkWs: {Kind: utpx.StaticShift}},
+		},
+		{ // State 174
+			Gotos:   map[TokenType]int{TkWs: 50, TkSws: 178},
+			Actions: map[TokenType]utpx.StaticAction{TkVar: {Kind: utpx.StaticReduce, Rule: 37}, TkString: {Kind: utpx.StaticReduce, Rule: 37}, TkPath: {Kind: utpx.StaticReduce, Rule: 37}, TkIdent: {Kind: utpx.StaticReduce, Rule: 37}, TkNumber: {Kind: utpx.StaticReduce, Rule: 37}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 37}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 37}, TkWs: {Kind: utpx.StaticShift}},
+		},
+		{ // State 175
+			Gotos:   map[TokenType]int{TkDeclare: 179},
+			Actions: map[TokenType]utpx.StaticAction{TkDeclare: {Kind: utpx.StaticShift}},
+		},
+		{ // State 176
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkVar: {Kind: utpx.StaticReduce, Rule: 44}, TkString: {Kind: utpx.StaticReduce, Rule: 44}, TkPath: {Kind: utpx.StaticReduce, Rule: 44}, TkIdent: {Kind: utpx.StaticReduce, Rule: 44}, TkNumber: {Kind: utpx.StaticReduce, Rule: 44}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 44}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 44}},
+		},
+		{ // State 177
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkVar: {Kind: utpx.StaticReduce, Rule: 38}, TkString: {Kind: utpx.StaticReduce, Rule: 38}, TkPath: {Kind: utpx.StaticReduce, Rule: 38}, TkIdent: {Kind: utpx.StaticReduce, Rule: 38}, TkNumber: {Kind: utpx.StaticReduce, Rule: 38}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 38}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 38}},
+		},
+		{ // State 178
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkVar: {Kind: utpx.StaticReduce, Rule: 36}, TkString: {Kind: utpx.StaticReduce, Rule: 36}, TkPath: {Kind: utpx.StaticReduce, Rule: 36}, TkIdent: {Kind: utpx.StaticReduce, Rule: 36}, TkNumber: {Kind: utpx.StaticReduce, Rule: 36}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 36}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 36}},
+		},
+		{ // State 179
+			Gotos:   map[TokenType]int{TkWs: 50, TkSws: 180},
+			Actions: map[TokenType]utpx.StaticAction{TkVar: {Kind: utpx.StaticReduce, Rule: 35}, TkString: {Kind: utpx.StaticReduce, Rule: 35}, TkPath: {Kind: utpx.StaticReduce, Rule: 35}, TkIdent: {Kind: utpx.StaticReduce, Rule: 35}, TkNumber: {Kind: utpx.StaticReduce, Rule: 35}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 35}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 35}, TkWs: {Kind: utpx.StaticShift}},
+		},
+		{ // State 180
+			Gotos:   map[TokenType]int{},
+			Actions: map[TokenType]utpx.StaticAction{TkVar: {Kind: utpx.StaticReduce, Rule: 34}, TkString: {Kind: utpx.StaticReduce, Rule: 34}, TkPath: {Kind: utpx.StaticReduce, Rule: 34}, TkIdent: {Kind: utpx.StaticReduce, Rule: 34}, TkNumber: {Kind: utpx.StaticReduce, Rule: 34}, TkKwTrue: {Kind: utpx.StaticReduce, Rule: 34}, TkKwFalse: {Kind: utpx.StaticReduce, Rule: 34}},
+		},
+	},
+}
@@ -0,0 +1,941 @@
+package parsing
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	utpx "github.com/PlayerR9/go_generator/util/parsing"
+	uc "github.com/PlayerR9/lib_units/common"
+)
+
+const (
+	// DefaultOpen is the default delimiter that opens an action.
+	DefaultOpen string = "{{"
+
+	// DefaultClose is the default delimiter that closes an action.
+	DefaultClose string = "}}"
+)
+
+// lex_mode is the mode of the lexer.
+type lex_mode int
+
+const (
+	// text_mode is the mode outside of actions: everything up to the next op_curly
+	// is text.
+	text_mode lex_mode = iota
+
+	// action_mode is the mode between op_curly and cl_curly.
+	action_mode
+)
+
+// Lexer is a lexical analyzer for the template. It reads the input lazily and delivers
+// the tokens one at a time.
+type Lexer struct {
+	// reader is the input stream.
+	reader io.RuneReader
+
+	// ahead are the runes read from the input stream but not consumed yet.
+	ahead []rune
+
+	// open is the delimiter that opens an action.
+	open string
+
+	// close is the delimiter that closes an action.
+	close string
+
+	// pos is the position of the next rune in the source.
+	pos utpx.Position
+
+	// mode is the current mode of the lexer.
+	mode lex_mode
+
+	// tokens are the tokens lexed but not delivered yet.
+	tokens []*utpx.Token[TokenType]
+
+	// count is the number of delivered tokens.
+	count int
+
+	// is_eof is true if the EOF token was delivered.
+	is_eof bool
+
+	// err is the error that stopped the lexer. Nil if there is none.
+	err error
+}
+
+// NewLexer creates a new lexer.
+//
+// Parameters:
+//   - r: The input stream.
+//
+// Returns:
+//   - *Lexer: The new lexer. Nil if r is nil.
+func NewLexer(r io.RuneReader) *Lexer {
+	if r == nil {
+		return nil
+	}
+
+	return &Lexer{
+		reader: r,
+		pos:    utpx.Position{Offset: 0, Line: 1, Column: 1},
+		open:   DefaultOpen,
+		close:  DefaultClose,
+	}
+}
+
+// SetDelims sets the delimiters of the actions. It must be called before the first
+// token is lexed.
+//
+// Parameters:
+//   - open: The delimiter that opens an action. If empty, DefaultOpen is used.
+//   - close: The delimiter that closes an action. If empty, DefaultClose is used.
+//
+// Returns:
+//   - error: An error if a delimiter is invalid.
+//
+// Errors:
+//   - *common.ErrInvalidParameter: If a delimiter contains a whitespace.
+func (l *Lexer) SetDelims(open, close string) error {
+	if open == "" {
+		open = DefaultOpen
+	}
+
+	if close == "" {
+		close = DefaultClose
+	}
+
+	if strings.IndexFunc(open, is_ws) >= 0 {
+		return uc.NewErrInvalidParameter("open", fmt.Errorf("delimiter %q contains a whitespace", open))
+	}
+
+	if strings.IndexFunc(close, is_ws) >= 0 {
+		return uc.NewErrInvalidParameter("close", fmt.Errorf("delimiter %q contains a whitespace", close))
+	}
+
+	l.open = open
+	l.close = close
+
+	return nil
+}
+
+// read is a helper function that reads runes of the input stream into the lookahead
+// buffer until it holds at least n runes.
+//
+// Parameters:
+//   - n: The number of runes the buffer must hold.
+//
+// Returns:
+//   - bool: True if the buffer holds at least n runes, false otherwise.
+//
+// Errors are stored in l.err; except io.EOF which only ends the input.
+func (l *Lexer) read(n int) bool {
+	for len(l.ahead) < n {
+		if l.err != nil {
+			return false
+		}
+
+		c, size, err := l.reader.ReadRune()
+		if err == io.EOF {
+			return false
+		} else if err != nil {
+			l.err = utpx.NewErrSyntax(l.count, l.pos, err)
+
+			return false
+		} else if c == utf8.RuneError && size == 1 {
+			l.err = utpx.NewErrSyntax(l.count, l.pos, errors.New("invalid utf-8 encoding"))
+
+			return false
+		}
+
+		l.ahead = append(l.ahead, c)
+	}
+
+	return true
+}
+
+// next is a helper function that returns the next rune in the input stream.
+//
+// Returns:
+//   - rune: The next rune in the input stream.
+//   - bool: True if the next rune is valid, false otherwise.
+//
+// utf8.RuneError is returned whenever the function returns false.
+func (l *Lexer) next() (rune, bool) {
+	if !l.read(1) {
+		return utf8.RuneError, false
+	}
+
+	first := l.ahead[0]
+	l.ahead = l.ahead[1:]
+
+	l.pos.Offset += utf8.RuneLen(first)
+
+	if first == '\n' {
+		l.pos.Line++
+		l.pos.Column = 1
+	} else {
+		l.pos.Column++
+	}
+
+	return first, true
+}
+
+// peek is a helper function that returns the next rune in the input stream without consuming it.
+//
+// Returns:
+//   - rune: The next rune in the input stream.
+//   - bool: True if the next rune is valid, false otherwise.
+//
+// utf8.RuneError is returned whenever the function returns false.
+func (l *Lexer) peek() (rune, bool) {
+	return l.peek_at(0)
+}
+
+// peek_at is a helper function that returns the rune at the given index of the input
+// stream, from the next rune, without consuming it.
+//
+// Parameters:
+//   - idx: The index of the rune. 0 is the next rune.
+//
+// Returns:
+//   - rune: The rune at the given index.
+//   - bool: True if the rune is valid, false otherwise.
+//
+// utf8.RuneError is returned whenever the function returns false.
+func (l *Lexer) peek_at(idx int) (rune, bool) {
+	if !l.read(idx + 1) {
+		return utf8.RuneError, false
+	}
+
+	return l.ahead[idx], true
+}
+
+// has_prefix is a helper function that checks if the input stream, from the next rune,
+// starts with the given string. Nothing is consumed.
+//
+// Parameters:
+//   - prefix: The string to check.
+//
+// Returns:
+//   - bool: True if the input starts with prefix, false otherwise.
+func (l *Lexer) has_prefix(prefix string) bool {
+	var i int
+
+	for _, c := range prefix {
+		next, ok := l.peek_at(i)
+		if !ok || next != c {
+			return false
+		}
+
+		i++
+	}
+
+	return true
+}
+
+// skip is a helper function that consumes as many runes as there are in the given string.
+//
+// Parameters:
+//   - str: The string to skip. It is assumed to be the prefix of the input stream.
+func (l *Lexer) skip(str string) {
+	for range str {
+		l.next() // consume
+	}
+}
+
+// emit is a helper function that adds a token to the pending tokens.
+//
+// Parameters:
+//   - typ: The type of the token.
+//   - data: The data of the token.
+//   - start: The position of the first character of the token.
+//   - end: The position right after the last character of the token.
+func (l *Lexer) emit(typ TokenType, data string, start, end utpx.Position) {
+	tk := utpx.NewToken(typ, data, nil)
+	tk.SetSpan(start, end)
+
+	l.tokens = append(l.tokens, tk)
+}
+
+// is_letter is a helper function that checks if the given rune is a letter of a variable
+// name.
+//
+// Parameters:
+//   - c: The rune to check.
+//
+// Returns:
+//   - bool: True if c is a letter, false otherwise.
+func is_letter(c rune) bool {
+	return c == '_' || unicode.IsLetter(c)
+}
+
+// keywords are the keywords of the template language.
+var keywords map[string]TokenType = map[string]TokenType{
+	"if":       TkKwIf,
+	"else":     TkKwElse,
+	"end":      TkKwEnd,
+	"range":    TkKwRange,
+	"true":     TkKwTrue,
+	"false":    TkKwFalse,
+	"define":   TkKwDefine,
+	"template": TkKwTemplate,
+	"block":    TkKwBlock,
+}
+
+// read_ident is a helper function that reads an identifier into the builder.
+//
+// Here's the EBNF rule for an identifier:
+//
+//	ident = letter { letter | digit } .
+//	letter = "A".."Z" | "a".."z" | "_" .
+//
+// Identifiers are the identifiers of Go; so they name functions, struct fields and map keys.
+//
+// Parameters:
+//   - builder: The builder to write the identifier to.
+//
+// Returns:
+//   - bool: True if an identifier was read, false otherwise.
+func (l *Lexer) read_ident(builder *strings.Builder) bool {
+	curr, ok := l.peek()
+	if !ok || !is_letter(curr) {
+		return false
+	}
+
+	for ok && (is_letter(curr) || unicode.IsDigit(curr)) {
+		builder.WriteRune(curr)
+
+		l.next() // consume
+
+		curr, ok = l.peek()
+	}
+
+	return true
+}
+
+// lex_ident is a helper function that lexes an identifier or, if the identifier is a
+// keyword (e.g., "if"), that keyword.
+//
+// Returns:
+//   - bool: True if the identifier is valid, false otherwise.
+func (l *Lexer) lex_ident() bool {
+	start := l.pos
+
+	var builder strings.Builder
+
+	ok := l.read_ident(&builder)
+	if !ok {
+		return false
+	}
+
+	name := builder.String()
+
+	typ, ok := keywords[name]
+	if !ok {
+		typ = TkIdent
+	}
+
+	l.emit(typ, name, start, l.pos)
+
+	return true
+}
+
+// lex_path is a helper function that lexes a field path or a variable reference.
+//
+// Here's the EBNF rule for them:
+//
+//	path = "." [ ident { "." ident } ] .
+//	var = "$" [ ident ] { "." ident } .
+//
+// The whole path is a single token so that "$v.Name" is never mistaken for the two
+// operands "$v" and ".Name".
+//
+// Returns:
+//   - error: An error if the path is invalid.
+func (l *Lexer) lex_path() error {
+	start := l.pos
+
+	first, _ := l.next()
+
+	var builder strings.Builder
+
+	builder.WriteRune(first)
+
+	typ := TkPath
+
+	if first == '$' {
+		typ = TkVar
+
+		l.read_ident(&builder)
+	} else {
+		// A lone dot is the dot itself.
+		l.read_ident(&builder)
+
+		if builder.Len() == 1 {
+			l.emit(typ, ".", start, l.pos)
+
+			return nil
+		}
+	}
+
+	for {
+		curr, ok := l.peek()
+		if !ok || curr != '.' {
+			break
+		}
+
+		l.next() // consume
+
+		builder.WriteRune(curr)
+
+		ok = l.read_ident(&builder)
+		if !ok {
+			return fmt.Errorf("expected a field name after %q", builder.String())
+		}
+	}
+
+	l.emit(typ, builder.String(), start, l.pos)
+
+	return nil
+}
+
+// lex_string is a helper function that lexes a string literal.
+//
+// Here's the EBNF rule for a string:
+//
+//	string = "\"" { %c | "\\" %c } "\"" | "`" { %c } "`" .
+//
+// The escape sequences are those of Go; they are checked when the literal is evaluated.
+//
+// Returns:
+//   - error: An error if the string is not terminated.
+func (l *Lexer) lex_string() error {
+	start := l.pos
+
+	quote, _ := l.next()
+
+	var builder strings.Builder
+
+	builder.WriteRune(quote)
+
+	for {
+		curr, ok := l.next()
+		if !ok || (curr == '\n' && quote == '"') {
+			return fmt.Errorf("unterminated string")
+		}
+
+		builder.WriteRune(curr)
+
+		if curr == quote {
+			break
+		} else if curr != '\\' || quote == '`' {
+			continue
+		}
+
+		curr, ok = l.next()
+		if !ok {
+			return fmt.Errorf("unterminated string")
+		}
+
+		builder.WriteRune(curr)
+	}
+
+	l.emit(TkString, builder.String(), start, l.pos)
+
+	return nil
+}
+
+// lex_comment is a helper function that lexes a comment. Comments may span several lines.
+//
+// Here's the EBNF rule for a comment:
+//
+//	comment = "/*" { %c } "*/" .
+//
+// Returns:
+//   - error: An error if the comment is not terminated.
+func (l *Lexer) lex_comment() error {
+	start := l.pos
+
+	l.next() // consume
+
+	next, ok := l.peek()
+	if !ok {
+		return fmt.Errorf("expected '*' after '/', got nothing instead")
+	} else if next != '*' {
+		return fmt.Errorf("expected '*' after '/', got %q instead", next)
+	}
+
+	l.next() // consume
+
+	var builder strings.Builder
+
+	builder.WriteString("/*")
+
+	prev := rune(0)
+
+	for {
+		curr, ok := l.next()
+		if !ok {
+			return fmt.Errorf("unterminated comment")
+		}
+
+		builder.WriteRune(curr)
+
+		if prev == '*' && curr == '/' {
+			break
+		}
+
+		prev = curr
+	}
+
+	l.emit(TkComment, builder.String(), start, l.pos)
+
+	return nil
+}
+
+// lex_number is a helper function that lexes a number literal.
+//
+// Here's the EBNF rule for a number:
+//
+//	number = [ "-" ] digit { digit } [ "." digit { digit } ] .
+//
+// Returns:
+//   - error: An error if the number is invalid.
+func (l *Lexer) lex_number() error {
+	start := l.pos
+
+	var builder strings.Builder
+
+	curr, ok := l.peek()
+	if curr == '-' {
+		builder.WriteRune(curr)
+
+		l.next() // consume
+
+		curr, ok = l.peek()
+	}
+
+	if !ok || !unicode.IsDigit(curr) {
+		return fmt.Errorf("expected a digit after '-'")
+	}
+
+	seen_dot := false
+
+	for ok && (unicode.IsDigit(curr) || (curr == '.' && !seen_dot)) {
+		if curr == '.' {
+			seen_dot = true
+		}
+
+		builder.WriteRune(curr)
+
+		l.next() // consume
+
+		curr, ok = l.peek()
+	}
+
+	number := builder.String()
+
+	if strings.HasSuffix(number, ".") {
+		return fmt.Errorf("expected a digit after %q", number)
+	}
+
+	l.emit(TkNumber, number, start, l.pos)
+
+	return nil
+}
+
+// lex_text is a helper function that lexes the input in text mode; that is, everything
+// up to the next op_curly or the end of the input.
+//
+// Here's the EBNF rule for a text:
+//
+//	text = %c { %c } .
+//
+// If the text ends with an op_curly, or an op_trim, that token is lexed too and the lexer
+// switches to action mode. An op_trim must be followed by a whitespace so that "{{-3}}"
+// is an op_curly followed by a number.
+//
+// Returns:
+//   - error: Always nil.
+func (l *Lexer) lex_text() error {
+	start := l.pos
+
+	var builder strings.Builder
+
+	for {
+		curr, ok := l.peek()
+		if !ok {
+			break
+		}
+
+		if !l.has_prefix(l.open) {
+			builder.WriteRune(curr)
+
+			l.next() // consume
+
+			continue
+		}
+
+		if builder.Len() > 0 {
+			l.emit(TkText, builder.String(), start, l.pos)
+		}
+
+		delim := l.pos
+
+		l.skip(l.open)
+
+		l.mode = action_mode
+
+		next, ok := l.peek_at(1)
+		if !l.has_prefix("-") || !ok || !is_ws(next) {
+			// op_curly = "{{" .
+			l.emit(TkOpCurly, l.open, delim, l.pos)
+
+			return nil
+		}
+
+		l.next() // consume
+
+		// op_trim = "{{-" .
+		l.emit(TkOpTrim, l.open+"-", delim, l.pos)
+
+		return nil
+	}
+
+	if builder.Len() > 0 {
+		l.emit(TkText, builder.String(), start, l.pos)
+	}
+
+	return nil
+}
+
+// lex_action is a helper function that lexes a single token in action mode. When the
+// cl_curly, or the cl_trim, is lexed, the lexer switches back to text mode.
+//
+// Returns:
+//   - error: An error if the token is invalid, nil otherwise.
+func (l *Lexer) lex_action() error {
+	curr, ok := l.peek()
+	if !ok {
+		return fmt.Errorf("unexpected end of input; expected %q", l.close)
+	}
+
+	start := l.pos
+
+	if l.has_prefix(l.close) {
+		// cl_curly = "}}" .
+		l.lex_close(start, TkClCurly, l.close)
+
+		return nil
+	} else if l.has_prefix("-" + l.close) {
+		// cl_trim = "-}}" .
+		l.lex_close(start, TkClTrim, "-"+l.close)
+
+		return nil
+	}
+
+	switch curr {
+	case '.', '$':
+		err := l.lex_path()
+		if err != nil {
+			return err
+		}
+	case ' ', '\t', '\r', '\n':
+		// ws = ( " " | "\t" | "\r" | "\n" ) { " " | "\t" | "\r" | "\n" } .
+		var builder strings.Builder
+
+		for ok && is_ws(curr) {
+			builder.WriteRune(curr)
+
+			l.next() // consume
+
+			curr, ok = l.peek()
+		}
+
+		l.emit(TkWs, builder.String(), start, l.pos)
+	case ',':
+		// comma = "," .
+		l.next() // consume
+
+		l.emit(TkComma, ",", start, l.pos)
+	case ':':
+		l.next() // consume
+
+		// declare = ":=" .
+		next, ok := l.peek()
+		if !ok {
+			return fmt.Errorf("expected '=' after ':', got nothing instead")
+		} else if next != '=' {
+			return fmt.Errorf("expected '=' after ':', got %q instead", next)
+		}
+
+		l.next() // consume
+
+		l.emit(TkDeclare, ":=", start, l.pos)
+	case '|':
+		// pipe = "|" .
+		l.next() // consume
+
+		l.emit(TkPipe, "|", start, l.pos)
+	case '"', '`':
+		err := l.lex_string()
+		if err != nil {
+			return err
+		}
+	case '/':
+		err := l.lex_comment()
+		if err != nil {
+			return err
+		}
+	case '-', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+		err := l.lex_number()
+		if err != nil {
+			return err
+		}
+	default:
+		ok := l.lex_ident()
+		if !ok {
+			return fmt.Errorf("unexpected character %q", curr)
+		}
+	}
+
+	return nil
+}
+
+// lex_close is a helper function that lexes the delimiter that closes an action and
+// switches the lexer back to text mode.
+//
+// Parameters:
+//   - start: The position of the first character of the token.
+//   - typ: The type of the token. Either TkClCurly or TkClTrim.
+//   - data: The data of the token. (e.g., "}}" or "-}}") It is assumed to be the prefix
+//     of the input stream.
+func (l *Lexer) lex_close(start utpx.Position, typ TokenType, data string) {
+	l.skip(data)
+
+	l.emit(typ, data, start, l.pos)
+	l.mode = text_mode
+}
+
+// is_ws is a helper function that checks if the given rune is a whitespace of an action.
+//
+// Parameters:
+//   - c: The rune to check.
+//
+// Returns:
+//   - bool: True if c is a whitespace, false otherwise.
+func is_ws(c rune) bool {
+	return c == ' ' || c == '\t' || c == '\r' || c == '\n'
+}
+
+// lex_one is a helper function that lexes the next token(s) according to the mode of the
+// lexer.
+//
+// Returns:
+//   - error: An error if the token is invalid, nil otherwise.
+func (l *Lexer) lex_one() error {
+	if l.mode == action_mode {
+		return l.lex_action()
+	}
+
+	return l.lex_text()
+}
+
+// is_done is a helper function that checks if the lexer is done.
+//
+// Returns:
+//   - bool: True if the lexer is done, false otherwise.
+func (l *Lexer) is_done() bool {
+	return !l.read(1)
+}
+
+// resync is a helper function that skips the rest of a broken action up to the delimiter
+// that closes it (cl_curly or cl_trim). That delimiter is lexed so that the parser can
+// resume there too. The tokens of the action that were not delivered yet are discarded.
+func (l *Lexer) resync() {
+	l.tokens = nil
+
+	for l.mode == action_mode && l.read(1) {
+		start := l.pos
+
+		if l.has_prefix(l.close) {
+			l.lex_close(start, TkClCurly, l.close)
+		} else if l.has_prefix("-" + l.close) {
+			l.lex_close(start, TkClTrim, "-"+l.close)
+		} else {
+			l.next() // skip
+		}
+	}
+}
+
+// Next lexes and returns the next token. The last token is always an EOF token.
+//
+// The tokens are not linked through their Lookahead field; so that a token kept by the
+// caller does not keep the rest of the input alive.
+//
+// Returns:
+//   - *utpx.Token[TokenType]: The next token. It holds its position in the source.
+//   - error: An error if the input could not be lexed.
+//
+// Errors:
+//   - *common.ErrExhaustedIter: If the EOF token was already returned.
+//   - *utpx.ErrSyntax: If the input is invalid or could not be read. After an invalid
+//     token, the lexer skips the rest of the action and resumes at the delimiter that
+//     closes it; so that the next call returns that delimiter. Otherwise, the same error is
+//     returned by every subsequent call.
+func (l *Lexer) Next() (*utpx.Token[TokenType], error) {
+	if l.err != nil {
+		return nil, l.err
+	}
+
+	for len(l.tokens) == 0 {
+		if l.is_eof {
+			return nil, uc.NewErrExhaustedIter()
+		}
+
+		if l.is_done() {
+			if l.err == nil && l.mode == action_mode {
+				l.err = utpx.NewErrSyntax(l.count, l.pos, fmt.Errorf("unexpected end of input; expected %q", l.close))
+			}
+
+			if l.err != nil {
+				return nil, l.err
+			}
+
+			eof := utpx.NewToken(TkEOF, "", nil)
+			eof.SetSpan(l.pos, l.pos)
+
+			l.tokens = append(l.tokens, eof)
+			l.is_eof = true
+
+			break
+		}
+
+		pos := l.pos
+
+		err := l.lex_one()
+		if l.err != nil {
+			return nil, l.err
+		} else if err != nil {
+			err = utpx.NewErrSyntax(l.count, pos, err)
+
+			l.resync()
+
+			return nil, err
+		}
+	}
+
+	tk := l.tokens[0]
+	l.tokens = l.tokens[1:]
+
+	l.count++
+
+	return tk, nil
+}
+
+// Consume implements the common.Iterater interface.
+//
+// It is the same as Next.
+func (l *Lexer) Consume() (*utpx.Token[TokenType], error) {
+	return l.Next()
+}
+
+// Restart implements the common.Iterater interface.
+//
+// The lexer starts over from the beginning of the input stream if the stream implements
+// io.Seeker. Otherwise, it does nothing.
+func (l *Lexer) Restart() {
+	seeker, ok := l.reader.(io.Seeker)
+	if !ok {
+		return
+	}
+
+	_, err := seeker.Seek(0, io.SeekStart)
+	if err != nil {
+		return
+	}
+
+	*l = Lexer{
+		reader: l.reader,
+		pos:    utpx.Position{Offset: 0, Line: 1, Column: 1},
+		open:   l.open,
+		close:  l.close,
+	}
+}
+
+// Lex lexes the given string with the default delimiters.
+//
+// Parameters:
+//   - str: The string to lex.
+//
+// Returns:
+//   - []*utpx.Token[TokenType]: The tokens; ending with an EOF token. See LexDelims.
+//   - error: An error if the string could not be lexed.
+//
+// Errors:
+//   - *common.ErrInvalidParameter: If str is empty.
+//   - *utpx.ErrSyntax: If str contains an invalid character. See LexDelims.
+func Lex(str string) ([]*utpx.Token[TokenType], error) {
+	return LexDelims(str, DefaultOpen, DefaultClose)
+}
+
+// LexDelims lexes the given string with the given delimiters.
+//
+// Parameters:
+//   - str: The string to lex.
+//   - open: The delimiter that opens an action. If empty, DefaultOpen is used.
+//   - close: The delimiter that closes an action. If empty, DefaultClose is used.
+//
+// Returns:
+//   - []*utpx.Token[TokenType]: The tokens; ending with an EOF token. Each token holds its
+//     position in str and is linked to the next one through its Lookahead field. On
+//     error, the tokens that could be lexed; the rest of a broken action is skipped.
+//   - error: An error if the string could not be lexed.
+//
+// Errors:
+//   - *common.ErrInvalidParameter: If str is empty or if a delimiter is invalid.
+//   - *utpx.ErrSyntax: If str contains an invalid character. Every broken action is
+//     reported; the errors are joined with errors.Join.
+func LexDelims(str, open, close string) ([]*utpx.Token[TokenType], error) {
+	if len(str) == 0 {
+		return nil, uc.NewErrInvalidParameter("str", uc.NewErrEmpty(str))
+	}
+
+	l := NewLexer(strings.NewReader(str))
+
+	err := l.SetDelims(open, close)
+	if err != nil {
+		return nil, err
+	}
+
+	var tokens []*utpx.Token[TokenType]
+	var errs []error
+
+	for {
+		tk, err := l.Next()
+		if err == nil {
+			tokens = append(tokens, tk)
+
+			continue
+		}
+
+		_, ok := err.(*uc.ErrExhaustedIter)
+		if ok {
+			for i := 0; i < len(tokens)-1; i++ {
+				tokens[i].Lookahead = tokens[i+1]
+			}
+
+			return tokens, errors.Join(errs...)
+		}
+
+		errs = append(errs, err)
+
+		if l.err != nil {
+			// The lexer cannot resume.
+			return tokens, errors.Join(errs...)
+		}
+	}
+}
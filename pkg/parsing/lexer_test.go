@@ -0,0 +1,316 @@
+package parsing
+
+import (
+	"bufio"
+	"errors"
+	"strings"
+	"testing"
+
+	utpx "github.com/PlayerR9/go_generator/util/parsing"
+	uc "github.com/PlayerR9/lib_units/common"
+)
+
+func TestLexer(t *testing.T) {
+	tokens, err := Lex("{{ .A }} {{ .B }} my_test")
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	expected := []TokenType{
+		TkOpCurly, TkWs, TkPath, TkWs, TkClCurly,
+		TkText,
+		TkOpCurly, TkWs, TkPath, TkWs, TkClCurly,
+		TkText,
+		TkEOF,
+	}
+
+	if len(tokens) != len(expected) {
+		t.Fatalf("expected %d tokens, got %d", len(expected), len(tokens))
+	}
+
+	for i, tk := range tokens {
+		if tk.Type != expected[i] {
+			t.Errorf("expected %s at token %d, got %s", expected[i].GoString(), i, tk.Type.GoString())
+		}
+	}
+
+	if tokens[11].Data != " my_test" {
+		t.Errorf("expected \" my_test\", got %q", tokens[11].Data)
+	}
+}
+
+func TestLexerTextMode(t *testing.T) {
+	str := "Hello World {\n\t}} {{ .Name }}\n"
+
+	tokens, err := Lex(str)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	if len(tokens) != 8 {
+		t.Fatalf("expected 8 tokens, got %d", len(tokens))
+	}
+
+	if tokens[0].Type != TkText || tokens[0].Data != "Hello World {\n\t}} " {
+		t.Errorf("expected the text before the action, got %s", tokens[0].String())
+	}
+
+	if tokens[7].Type != TkEOF {
+		t.Errorf("expected end of file, got %s", tokens[7].Type.GoString())
+	}
+
+	start := utpx.Position{Offset: 18, Line: 2, Column: 5}
+
+	if tokens[1].Start != start {
+		t.Errorf("expected op_curly at %s, got %s", start, tokens[1].Start)
+	}
+
+	_, err = Parse(tokens)
+	if err != nil {
+		t.Errorf("expected no error, got %s", err.Error())
+	}
+}
+
+func TestLexerActionMode(t *testing.T) {
+	_, err := Lex("Hello {{ .Name + }}")
+	if err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+
+	expected := "1:16: unexpected character '+'"
+
+	if err.Error() != expected {
+		t.Errorf("expected %q, got %q", expected, err.Error())
+	}
+}
+
+func TestLexerLiterals(t *testing.T) {
+	tokens, err := Lex(`{{ $x := printf "%s\"" $v.Name -1.5 true | lower }}`)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	expected := []struct {
+		typ  TokenType
+		data string
+	}{
+		{TkOpCurly, "{{"}, {TkWs, " "}, {TkVar, "$x"}, {TkWs, " "}, {TkDeclare, ":="}, {TkWs, " "},
+		{TkIdent, "printf"}, {TkWs, " "}, {TkString, `"%s\""`}, {TkWs, " "}, {TkVar, "$v.Name"},
+		{TkWs, " "}, {TkNumber, "-1.5"}, {TkWs, " "}, {TkKwTrue, "true"}, {TkWs, " "}, {TkPipe, "|"},
+		{TkWs, " "}, {TkIdent, "lower"}, {TkWs, " "}, {TkClCurly, "}}"}, {TkEOF, ""},
+	}
+
+	if len(tokens) != len(expected) {
+		t.Fatalf("expected %d tokens, got %d", len(expected), len(tokens))
+	}
+
+	for i, tk := range tokens {
+		if tk.Type != expected[i].typ || tk.Data != expected[i].data {
+			t.Errorf("expected %s(%q) at token %d, got %s", expected[i].typ.GoString(), expected[i].data, i, tk.String())
+		}
+	}
+
+	_, err = Lex(`{{ "abc }}`)
+	if err == nil {
+		t.Errorf("expected error, got nil")
+	}
+}
+
+func TestLexerTrimMarkers(t *testing.T) {
+	tokens, err := Lex("a {{- .X -}} b{{-3}}{{ 1-}}")
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	expected := []struct {
+		typ  TokenType
+		data string
+	}{
+		{TkText, "a "}, {TkOpTrim, "{{-"}, {TkWs, " "}, {TkPath, ".X"}, {TkWs, " "}, {TkClTrim, "-}}"},
+		{TkText, " b"}, {TkOpCurly, "{{"}, {TkNumber, "-3"}, {TkClCurly, "}}"},
+		{TkOpCurly, "{{"}, {TkWs, " "}, {TkNumber, "1"}, {TkClTrim, "-}}"}, {TkEOF, ""},
+	}
+
+	if len(tokens) != len(expected) {
+		t.Fatalf("expected %d tokens, got %d", len(expected), len(tokens))
+	}
+
+	for i, tk := range tokens {
+		if tk.Type != expected[i].typ || tk.Data != expected[i].data {
+			t.Errorf("expected %s(%q) at token %d, got %s", expected[i].typ.GoString(), expected[i].data, i, tk.String())
+		}
+	}
+}
+
+func TestLexerComments(t *testing.T) {
+	tokens, err := Lex("a{{/* line 1\n * line 2 */}}{{- /**/ -}}")
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	expected := []struct {
+		typ  TokenType
+		data string
+	}{
+		{TkText, "a"}, {TkOpCurly, "{{"}, {TkComment, "/* line 1\n * line 2 */"}, {TkClCurly, "}}"},
+		{TkOpTrim, "{{-"}, {TkWs, " "}, {TkComment, "/**/"}, {TkWs, " "}, {TkClTrim, "-}}"}, {TkEOF, ""},
+	}
+
+	if len(tokens) != len(expected) {
+		t.Fatalf("expected %d tokens, got %d", len(expected), len(tokens))
+	}
+
+	for i, tk := range tokens {
+		if tk.Type != expected[i].typ || tk.Data != expected[i].data {
+			t.Errorf("expected %s(%q) at token %d, got %s", expected[i].typ.GoString(), expected[i].data, i, tk.String())
+		}
+	}
+
+	if tokens[3].Start.Line != 2 {
+		t.Errorf("expected the close curly on line 2, got line %d", tokens[3].Start.Line)
+	}
+
+	for _, str := range []string{"{{/* abc }}", "{{/ abc }}"} {
+		_, err = Lex(str)
+		if err == nil {
+			t.Errorf("expected error for %q, got nil", str)
+		}
+	}
+}
+
+func TestLexerDelims(t *testing.T) {
+	tokens, err := LexDelims("{{ x }}<% .A %><%- -3 -%>", "<%", "%>")
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	expected := []struct {
+		typ  TokenType
+		data string
+	}{
+		{TkText, "{{ x }}"}, {TkOpCurly, "<%"}, {TkWs, " "}, {TkPath, ".A"}, {TkWs, " "}, {TkClCurly, "%>"},
+		{TkOpTrim, "<%-"}, {TkWs, " "}, {TkNumber, "-3"}, {TkWs, " "}, {TkClTrim, "-%>"}, {TkEOF, ""},
+	}
+
+	if len(tokens) != len(expected) {
+		t.Fatalf("expected %d tokens, got %d", len(expected), len(tokens))
+	}
+
+	for i, tk := range tokens {
+		if tk.Type != expected[i].typ || tk.Data != expected[i].data {
+			t.Errorf("expected %s(%q) at token %d, got %s", expected[i].typ.GoString(), expected[i].data, i, tk.String())
+		}
+	}
+
+	_, err = LexDelims("a <% .A", "<%", "%>")
+	if err == nil || !strings.Contains(err.Error(), `expected "%>"`) {
+		t.Errorf("expected an error about the close delimiter, got %v", err)
+	}
+
+	_, err = LexDelims("a", "< %", "%>")
+	if err == nil {
+		t.Errorf("expected error for a delimiter with a whitespace, got nil")
+	}
+}
+
+func TestLexerRecovery(t *testing.T) {
+	str := "a {{ .A ) }} b {{ .B ) -}} c"
+
+	tokens, err := Lex(str)
+	if err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+
+	for _, want := range []string{"1:9: unexpected character ')'", "1:22: unexpected character ')'"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in %q", want, err.Error())
+		}
+	}
+
+	// The lexer resumes at the delimiters that close the broken actions.
+	expected := []TokenType{
+		TkText, TkOpCurly, TkWs, TkPath, TkWs, TkClCurly,
+		TkText, TkOpCurly, TkWs, TkPath, TkWs, TkClTrim,
+		TkText, TkEOF,
+	}
+
+	if len(tokens) != len(expected) {
+		t.Fatalf("expected %d tokens, got %d", len(expected), len(tokens))
+	}
+
+	for i, tk := range tokens {
+		if tk.Type != expected[i] {
+			t.Errorf("expected %s at token %d, got %s", expected[i].GoString(), i, tk.Type.GoString())
+		}
+	}
+
+	_, err = ParseReader(strings.NewReader(str))
+	if err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+
+	if n := strings.Count(err.Error(), "unexpected character"); n != 2 {
+		t.Errorf("expected 2 errors, got %q", err.Error())
+	}
+}
+
+func TestLexerNext(t *testing.T) {
+	if NewLexer(nil) != nil {
+		t.Errorf("expected nil lexer for a nil reader")
+	}
+
+	l := NewLexer(strings.NewReader("a {{ .A }}"))
+
+	expected := []TokenType{TkText, TkOpCurly, TkWs, TkPath, TkWs, TkClCurly, TkEOF}
+
+	for i, typ := range expected {
+		tk, err := l.Next()
+		if err != nil {
+			t.Fatalf("expected no error at token %d, got %s", i, err.Error())
+		}
+
+		if tk.Type != typ {
+			t.Errorf("expected %s at token %d, got %s", typ.GoString(), i, tk.Type.GoString())
+		}
+
+		if tk.Lookahead != nil {
+			t.Errorf("expected token %d not to be linked to the next one", i)
+		}
+	}
+
+	var exhausted *uc.ErrExhaustedIter
+
+	_, err := l.Consume()
+	if !errors.As(err, &exhausted) {
+		t.Fatalf("expected *common.ErrExhaustedIter, got %v", err)
+	}
+
+	// A strings.Reader can be rewound.
+	l.Restart()
+
+	tk, err := l.Consume()
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	if tk.Type != TkText || tk.Data != "a " || tk.Start.Offset != 0 {
+		t.Errorf("expected the first token again, got %s", tk.String())
+	}
+
+	// A bufio.Reader cannot.
+	l = NewLexer(bufio.NewReader(strings.NewReader("a")))
+
+	for i := 0; i < 2; i++ {
+		_, err = l.Next()
+		if err != nil {
+			t.Fatalf("expected no error, got %s", err.Error())
+		}
+	}
+
+	l.Restart()
+
+	_, err = l.Next()
+	if !errors.As(err, &exhausted) {
+		t.Errorf("expected *common.ErrExhaustedIter, got %v", err)
+	}
+}
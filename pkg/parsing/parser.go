@@ -0,0 +1,104 @@
+package parsing
+
+import (
+	"errors"
+	"io"
+
+	utpx "github.com/PlayerR9/go_generator/util/parsing"
+	uc "github.com/PlayerR9/lib_units/common"
+)
+
+// new_parser is a helper function that creates the parser of the template.
+//
+// Syntax errors do not stop the parser: it skips to the end of the broken action
+// (cl_curly or cl_trim) and keeps going so that every broken action is reported.
+//
+// Returns:
+//   - *utpx.Parser[TokenType]: The parser. Never returns nil.
+func new_parser() *utpx.Parser[TokenType] {
+	p, err := utpx.NewParser(DecisionTable, TkClCurly, TkClTrim)
+	uc.AssertErr(err, "utpx.NewParser(DecisionTable, TkClCurly, TkClTrim)")
+
+	return p
+}
+
+// join_errors is a helper function that joins the syntax errors.
+//
+// Parameters:
+//   - errs: The syntax errors.
+//
+// Returns:
+//   - error: The errors joined with errors.Join. Nil if there are none.
+func join_errors(errs []*utpx.ErrSyntax) error {
+	if len(errs) == 0 {
+		return nil
+	}
+
+	joined := make([]error, 0, len(errs))
+
+	for _, err := range errs {
+		joined = append(joined, err)
+	}
+
+	return errors.Join(joined...)
+}
+
+// Parse is a helper function that parses the given tokens.
+//
+// Parameters:
+//   - tokens: The tokens to parse.
+//
+// Returns:
+//   - *utpx.Token[TokenType]: The parsed token. If there are errors, it is the partial tree.
+//   - error: The syntax errors, joined with errors.Join, if the parsing failed. Each one is
+//     of type *utpx.ErrSyntax.
+func Parse(tokens []*utpx.Token[TokenType]) (*utpx.Token[TokenType], error) {
+	root, errs := new_parser().ParseWithRecovery(tokens)
+
+	return root, join_errors(errs)
+}
+
+// ParseReader is like Parse but it lexes the template from the given reader, with the
+// default delimiters; one token at a time as the parser needs it.
+//
+// Parameters:
+//   - r: The reader of the template.
+//
+// Returns:
+//   - *utpx.Token[TokenType]: The parsed token. If there are errors, it is the partial tree.
+//   - error: The lexing and syntax errors, joined with errors.Join, if the parsing failed.
+func ParseReader(r io.RuneReader) (*utpx.Token[TokenType], error) {
+	return ParseReaderDelims(r, DefaultOpen, DefaultClose)
+}
+
+// ParseReaderDelims is like ParseReader but with the given delimiters.
+//
+// Parameters:
+//   - r: The reader of the template.
+//   - open: The delimiter that opens an action. If empty, DefaultOpen is used.
+//   - close: The delimiter that closes an action. If empty, DefaultClose is used.
+//
+// Returns:
+//   - *utpx.Token[TokenType]: The parsed token. If there are errors, it is the partial tree.
+//   - error: The lexing and syntax errors, joined with errors.Join, if the parsing failed.
+//
+// Errors:
+//   - *common.ErrNilParameter: If r is nil.
+//   - *common.ErrInvalidParameter: If a delimiter is invalid.
+//   - *utpx.ErrSyntax: For each broken action.
+func ParseReaderDelims(r io.RuneReader, open, close string) (*utpx.Token[TokenType], error) {
+	if r == nil {
+		return nil, uc.NewErrNilParameter("r")
+	}
+
+	l := NewLexer(r)
+
+	err := l.SetDelims(open, close)
+	if err != nil {
+		return nil, err
+	}
+
+	root, errs := new_parser().ParseIterWithRecovery(l)
+
+	return root, join_errors(errs)
+}
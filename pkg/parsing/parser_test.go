@@ -0,0 +1,92 @@
+package parsing
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	utpx "github.com/PlayerR9/go_generator/util/parsing"
+)
+
+func TestParseReader(t *testing.T) {
+	root, err := ParseReader(strings.NewReader("a {{ .A }}"))
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	if root.Type != TkSource {
+		t.Errorf("expected TkSource, got %s", root.Type.GoString())
+	}
+
+	_, err = ParseReader(nil)
+	if err == nil {
+		t.Errorf("expected error for a nil reader, got nil")
+	}
+
+	_, err = ParseReader(strings.NewReader("a {{ .A"))
+	if err == nil || !strings.Contains(err.Error(), `expected "}}"`) {
+		t.Errorf("expected an error about the close delimiter, got %v", err)
+	}
+}
+
+func TestParseReaderDelims(t *testing.T) {
+	// The default delimiters are plain text.
+	root, err := ParseReaderDelims(strings.NewReader("{{ x }}<% .A %>"), "<%", "%>")
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	if root.Type != TkSource || root.End.Offset != 15 {
+		t.Errorf("expected a TkSource up to offset 15, got %s", root.String())
+	}
+
+	// Every broken action is reported.
+	_, err = ParseReaderDelims(strings.NewReader("<% .A ) %> b <% .B ) %>"), "<%", "%>")
+	if err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+
+	if n := strings.Count(err.Error(), "unexpected character"); n != 2 {
+		t.Errorf("expected 2 errors, got %q", err.Error())
+	}
+
+	_, err = ParseReaderDelims(strings.NewReader("a"), "< %", "%>")
+	if err == nil {
+		t.Errorf("expected error for a delimiter with a whitespace, got nil")
+	}
+}
+
+func TestSttFunc(t *testing.T) {
+	// Every symbol of the grammar has a type.
+	_, err := utpx.NewDecisionTable(Grammar, SttFunc)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	for name, expected := range map[string]TokenType{"EOF": TkEOF, "cl_curly": TkClCurly, "Source": TkSource, "Sws1": TkSws1} {
+		typ, ok := SttFunc(name)
+		if !ok || typ != expected {
+			t.Errorf("expected %q to give %s, got %s", name, expected.GoString(), typ.GoString())
+		}
+	}
+
+	_, ok := SttFunc("cl curly")
+	if ok {
+		t.Errorf("expected no type for a description")
+	}
+}
+
+func BenchmarkParseReader(b *testing.B) {
+	for _, n := range []int{1000, 4000, 16000} {
+		str := strings.Repeat("var {{ .Name }} = {{ .Type }}; ", n/2)
+
+		b.Run(strconv.Itoa(n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, err := ParseReader(strings.NewReader(str))
+				if err != nil {
+					b.Fatalf("expected no error, got %s", err.Error())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,420 @@
+package pkg
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	uc "github.com/PlayerR9/lib_units/common"
+)
+
+// Partial applies the data to the template as far as it can and returns the template of
+// the next stage of the generation. Actions whose fields are known are replaced by their
+// text; the others are kept as they are. The template is not modified.
+//
+// More precisely:
+//   - An if block is kept from the first branch whose condition cannot be evaluated, if
+//     no previous branch was taken; its branches are partially applied.
+//   - A range block whose collection cannot be evaluated is kept as a whole; so is one
+//     whose body keeps an action that uses its variables or its dot.
+//   - An assignment is always kept so that the actions of the next stage can use its
+//     variable; with a literal if its value is a string, a number or a boolean.
+//   - A template action, or a block, whose pipeline cannot be evaluated is kept as a whole;
+//     so is one whose sub-template keeps an action that uses its dot. Otherwise, it is
+//     replaced by its sub-template partially applied.
+//   - Comments and definitions are kept. The next stage shares the sub-templates of this
+//     one.
+//
+// Parameters:
+//   - data: The data to apply.
+//
+// Returns:
+//   - *Template: The template of the next stage. Nil if an error occurs.
+//   - []string: The field paths that could not be resolved, in order of appearance and
+//     without duplicates. (e.g., [".Type.Name", "$x"])
+//   - error: An error if the data could not be applied for any other reason.
+func (t *Template) Partial(data any) (*Template, []string, error) {
+	value, err := data_value(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to apply template: %w", err)
+	}
+
+	var unresolved []string
+
+	nodes, err := t.partial(t.root.Children, new_scope(value, t.tag), &unresolved)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to apply template: %w", err)
+	}
+
+	root := NewNode(SourceNode, "")
+
+	for _, node := range nodes {
+		add_child(root, node)
+	}
+
+	for {
+		ok := simplify_ast(root)
+		if !ok {
+			break
+		}
+	}
+
+	return t.with_root(root), unresolved, nil
+}
+
+// is_unresolved is a helper function that checks whether an error is caused by a field
+// that cannot be resolved; if so, its path is added to the unresolved paths.
+//
+// Parameters:
+//   - err: The error.
+//   - unresolved: The unresolved paths.
+//
+// Returns:
+//   - bool: True if the error is caused by an unresolved field, false otherwise.
+func is_unresolved(err error, unresolved *[]string) bool {
+	var field_err *ErrField
+
+	if !errors.As(err, &field_err) {
+		return false
+	}
+
+	if !slices.Contains(*unresolved, field_err.Path) {
+		*unresolved = append(*unresolved, field_err.Path)
+	}
+
+	return true
+}
+
+// partial is a helper function that partially applies the data to the given nodes.
+//
+// Parameters:
+//   - nodes: The nodes to apply the data to.
+//   - sc: The scope of the nodes.
+//   - unresolved: The unresolved paths found so far.
+//
+// Returns:
+//   - []*Node: The new nodes once applied. The given nodes are not modified.
+//   - error: An error if the data could not be applied.
+func (t *Template) partial(nodes []*Node, sc *scope, unresolved *[]string) ([]*Node, error) {
+	uc.AssertParam("sc", sc != nil, errors.New("sc is nil"))
+
+	var result []*Node
+
+	for _, node := range nodes {
+		uc.AssertParam("node", node != nil, errors.New("node is nil"))
+
+		switch node.Kind {
+		case VariableNode:
+			value, err := t.eval_pipeline(node.Pipe, sc)
+			if is_unresolved(err, unresolved) {
+				result = append(result, copy_node(node))
+
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+
+			text, err := t.format_value(value)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", node.Data, err)
+			}
+
+			result = append(result, NewNode(TextNode, text))
+		case AssignNode:
+			value, err := t.eval_pipeline(node.Pipe, sc)
+			if is_unresolved(err, unresolved) {
+				delete(sc.vars, node.Vars[0])
+
+				result = append(result, copy_node(node))
+
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+
+			sc.vars[node.Vars[0]] = value
+
+			lit, ok := literal_text(value)
+			if !ok {
+				result = append(result, copy_node(node))
+
+				continue
+			}
+
+			assign := NewNode(AssignNode, lit)
+			assign.Vars = slices.Clone(node.Vars)
+			assign.Pipe = NewNode(PipelineNode, lit)
+
+			add_child(assign.Pipe, NewNode(LiteralNode, lit))
+
+			result = append(result, assign)
+		case TextNode:
+			result = append(result, NewNode(TextNode, node.Data))
+		case CommentNode, DefineNode:
+			result = append(result, copy_node(node))
+		case TemplateNode, BlockNode:
+			dot, err := t.template_dot(node, sc)
+			if is_unresolved(err, unresolved) {
+				result = append(result, copy_node(node))
+
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+
+			def, ok := t.defs[node.Data]
+			if !ok {
+				return nil, fmt.Errorf("template %q is not defined", node.Data)
+			}
+
+			sub_nodes, err := t.partial(def_body(def), sc.sub(dot), unresolved)
+			if err != nil {
+				return nil, err
+			}
+
+			if uses_scope(sub_nodes, nil, true) {
+				result = append(result, copy_node(node))
+
+				continue
+			}
+
+			result = append(result, sub_nodes...)
+		case IfNode:
+			sub_nodes, err := t.partial_if(node, sc, unresolved)
+			if err != nil {
+				return nil, err
+			}
+
+			result = append(result, sub_nodes...)
+		case RangeNode:
+			sub_nodes, err := t.partial_range(node, sc, unresolved)
+			if err != nil {
+				return nil, err
+			}
+
+			result = append(result, sub_nodes...)
+		default:
+			return nil, fmt.Errorf("invalid node: %s", node.Kind.String())
+		}
+	}
+
+	return result, nil
+}
+
+// partial_if is a helper function that partially applies the data to an if node.
+//
+// Parameters:
+//   - node: The if node.
+//   - sc: The scope of the node.
+//   - unresolved: The unresolved paths found so far.
+//
+// Returns:
+//   - []*Node: The nodes of the branch that was taken or the if node that was kept.
+//   - error: An error if the data could not be applied.
+func (t *Template) partial_if(node *Node, sc *scope, unresolved *[]string) ([]*Node, error) {
+	for i, branch := range node.Children {
+		if branch.Pipe == nil {
+			return t.partial(branch.Children, sc.with(sc.dot, nil, nil), unresolved)
+		}
+
+		cond, err := t.eval_pipeline(branch.Pipe, sc)
+		if is_unresolved(err, unresolved) {
+			kept := NewNode(IfNode, node.Data)
+
+			for _, other := range node.Children[i:] {
+				sub_nodes, err := t.partial(other.Children, sc.with(sc.dot, nil, nil), unresolved)
+				if err != nil {
+					return nil, err
+				}
+
+				cp := NewNode(BranchNode, other.Data)
+
+				if other.Pipe != nil {
+					cp.Pipe = copy_node(other.Pipe)
+				}
+
+				for _, sub_node := range sub_nodes {
+					add_child(cp, sub_node)
+				}
+
+				add_child(kept, cp)
+			}
+
+			return []*Node{kept}, nil
+		} else if err != nil {
+			return nil, err
+		}
+
+		if is_true(cond) {
+			return t.partial(branch.Children, sc.with(sc.dot, nil, nil), unresolved)
+		}
+	}
+
+	return nil, nil
+}
+
+// partial_range is a helper function that partially applies the data to a range node.
+//
+// Parameters:
+//   - node: The range node.
+//   - sc: The scope of the node.
+//   - unresolved: The unresolved paths found so far.
+//
+// Returns:
+//   - []*Node: The nodes of the body once per element, the nodes of the else branch or
+//     the range node that was kept. The range node is kept if the nodes kept in the body
+//     use its variables or its dot; since they could not be evaluated without them.
+//   - error: An error if the data could not be applied.
+func (t *Template) partial_range(node *Node, sc *scope, unresolved *[]string) ([]*Node, error) {
+	uc.AssertParam("node", len(node.Children) > 0, errors.New("range node has no body"))
+
+	value, err := t.eval_pipeline(node.Pipe, sc)
+	if is_unresolved(err, unresolved) {
+		return []*Node{copy_node(node)}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	keys, values, err := range_over(value)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", node.Data, err)
+	}
+
+	if len(values) == 0 {
+		if len(node.Children) < 2 {
+			return nil, nil
+		}
+
+		return t.partial(node.Children[1].Children, sc.with(sc.dot, nil, nil), unresolved)
+	}
+
+	var result []*Node
+
+	for i, elem := range values {
+		var sub_sc *scope
+
+		switch len(node.Vars) {
+		case 0:
+			sub_sc = sc.with(elem, nil, nil)
+		case 1:
+			sub_sc = sc.with(elem, node.Vars, []reflect.Value{elem})
+		default:
+			sub_sc = sc.with(elem, node.Vars, []reflect.Value{keys[i], elem})
+		}
+
+		sub_nodes, err := t.partial(node.Children[0].Children, sub_sc, unresolved)
+		if err != nil {
+			return nil, err
+		}
+
+		if uses_scope(sub_nodes, node.Vars, true) {
+			return []*Node{copy_node(node)}, nil
+		}
+
+		result = append(result, sub_nodes...)
+	}
+
+	return result, nil
+}
+
+// uses_scope is a helper function that checks whether the given nodes use the dot or
+// one of the given variables.
+//
+// Parameters:
+//   - nodes: The nodes.
+//   - vars: The variables. (e.g., ["$i", "$e"])
+//   - dot: Whether the use of the dot counts. The body of a range block has a dot of its
+//     own.
+//
+// Returns:
+//   - bool: True if the nodes use the dot or one of the variables, false otherwise.
+//
+// The bodies of the define and block nodes are not searched since they are sub-templates
+// of their own.
+func uses_scope(nodes []*Node, vars []string, dot bool) bool {
+	for _, node := range nodes {
+		switch node.Kind {
+		case FieldNode:
+			if dot && strings.HasPrefix(node.Data, ".") {
+				return true
+			}
+
+			for _, v := range vars {
+				if node.Data == v || strings.HasPrefix(node.Data, v+".") {
+					return true
+				}
+			}
+		case DefineNode, BlockNode:
+			if node.Pipe != nil && uses_scope([]*Node{node.Pipe}, vars, dot) {
+				return true
+			}
+
+			continue
+		case RangeNode:
+			// The else branch keeps the dot.
+			if uses_scope([]*Node{node.Pipe}, vars, dot) || uses_scope(node.Children[:1], vars, false) ||
+				uses_scope(node.Children[1:], vars, dot) {
+				return true
+			}
+
+			continue
+		}
+
+		if node.Pipe != nil && uses_scope([]*Node{node.Pipe}, vars, dot) {
+			return true
+		}
+
+		if uses_scope(node.Children, vars, dot) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// literal_text is a helper function that returns the literal of a value, as it would be
+// written in a template.
+//
+// Parameters:
+//   - value: The value.
+//
+// Returns:
+//   - string: The literal. (e.g., "\"a\"", "42", "1.5" or "true")
+//   - bool: True if the value is a string, a finite number or a boolean, false otherwise.
+func literal_text(value reflect.Value) (string, bool) {
+	elem, ok := indirect(value)
+	if !ok || !elem.IsValid() {
+		return "", false
+	}
+
+	switch elem.Kind() {
+	case reflect.String:
+		return strconv.Quote(elem.String()), true
+	case reflect.Bool:
+		return strconv.FormatBool(elem.Bool()), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(elem.Int(), 10), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(elem.Uint(), 10), true
+	case reflect.Float32, reflect.Float64:
+		f := elem.Float()
+
+		if math.IsInf(f, 0) || math.IsNaN(f) {
+			return "", false
+		}
+
+		text := strconv.FormatFloat(f, 'f', -1, 64)
+
+		if !strings.Contains(text, ".") {
+			text += ".0"
+		}
+
+		return text, true
+	}
+
+	return "", false
+}
@@ -0,0 +1,126 @@
+package pkg
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestPartial(t *testing.T) {
+	type First struct {
+		Name string
+		Ok   bool
+	}
+
+	type Type struct {
+		Sig string
+	}
+
+	type Second struct {
+		Type  Type
+		Later bool
+		Items []int
+	}
+
+	str := `{{ .Name }} {{ .Type.Sig }} {{ if .Ok }}A{{ else if .Later }}{{ .Name | upper }}{{ else }}C{{ end }}` +
+		`{{ range .Items }}{{ . }}{{ end }}{{ $n := .Name }}{{ $m := .Later }}{{ $m }} {{ $n }}`
+
+	tmpl, err := NewTemplate(str, WithFuncs(FuncMap{"upper": strings.ToUpper}))
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	next, unresolved, err := tmpl.Partial(First{Name: "stack"})
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	expected_unresolved := []string{".Type.Sig", ".Later", ".Items", "$m"}
+
+	if !slices.Equal(unresolved, expected_unresolved) {
+		t.Errorf("expected %v, got %v", expected_unresolved, unresolved)
+	}
+
+	var builder strings.Builder
+
+	err = next.Write(&builder)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	expected := `stack {{ .Type.Sig }} {{ if .Later }}STACK{{ else }}C{{ end }}` +
+		`{{ range .Items }}{{ . }}{{ end }}{{ $n := "stack" }}{{ $m := .Later }}{{ $m }} stack`
+
+	if builder.String() != expected {
+		t.Errorf("expected %q, got %q", expected, builder.String())
+	}
+
+	builder.Reset()
+
+	err = next.Execute(&builder, Second{Type: Type{Sig: "S[T]"}, Later: true, Items: []int{1, 2}})
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	expected = "stack S[T] STACK12true stack"
+
+	if builder.String() != expected {
+		t.Errorf("expected %q, got %q", expected, builder.String())
+	}
+
+	builder.Reset()
+
+	err = tmpl.Write(&builder)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	} else if !strings.HasPrefix(builder.String(), "{{ .Name }} ") {
+		t.Errorf("expected the template to be unchanged, got %q", builder.String())
+	}
+}
+
+func TestPartialRangeScope(t *testing.T) {
+	str := `{{ range $e := .Items }}[{{ $e.Y }}]{{ end }}{{ range $e := .Items }}[{{ $e.X }}]{{ end }}` +
+		`{{ range .Items }}({{ .X }}){{ end }}`
+
+	tmpl, err := NewTemplate(str)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	next, _, err := tmpl.Partial(map[string]any{"Items": []map[string]any{{"Y": 1}}})
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	var builder strings.Builder
+
+	err = next.Write(&builder)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	// The ranges whose bodies need their element are kept as a whole.
+	expected := `[1]{{ range $e := .Items }}[{{ $e.X }}]{{ end }}{{ range .Items }}({{ .X }}){{ end }}`
+
+	if builder.String() != expected {
+		t.Fatalf("expected %q, got %q", expected, builder.String())
+	}
+
+	reparsed, err := NewTemplate(builder.String())
+	if err != nil {
+		t.Fatalf("expected the output to be a valid template, got %s", err.Error())
+	}
+
+	builder.Reset()
+
+	err = reparsed.Execute(&builder, map[string]any{"Items": []map[string]any{{"X": 2}}})
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	expected = "[1][2](2)"
+
+	if builder.String() != expected {
+		t.Errorf("expected %q, got %q", expected, builder.String())
+	}
+}
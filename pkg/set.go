@@ -0,0 +1,395 @@
+package pkg
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	utpx "github.com/PlayerR9/go_generator/util/parsing"
+	uc "github.com/PlayerR9/lib_units/common"
+)
+
+// TemplateSet is a set of named templates. Each template can execute the others, and the
+// sub-templates that any of them defines with a define or a block action, by name with
+// the template action. (e.g., {{ template "header" . }})
+type TemplateSet struct {
+	// templates are the templates of the set, by name; including one for each sub-template.
+	templates map[string]*Template
+}
+
+// NewTemplateSet creates a new set of templates. The sub-templates executed by the
+// templates are resolved once every template is parsed; so the templates can refer to
+// each other in any order.
+//
+// Unlike Go templates, a sub-template cannot execute itself; directly or not.
+//
+// Parameters:
+//   - srcs: The template strings, by name. (e.g., {"stack": "...", "header": "..."})
+//   - opts: The options of every template of the set. (e.g., WithFuncs)
+//
+// Returns:
+//   - *TemplateSet: The set. Nil if an error occurs.
+//   - error: An error if a template is invalid.
+//
+// Errors:
+//   - *common.ErrInvalidParameter: If a template has no name.
+//   - *utpx.ErrSyntax: If a template is invalid, if a sub-template is defined twice or is
+//     not defined, or if a sub-template executes itself. The name of the template is the
+//     file of the error.
+func NewTemplateSet(srcs map[string]string, opts ...Option) (*TemplateSet, error) {
+	names := make([]string, 0, len(srcs))
+
+	for name := range srcs {
+		if name == "" {
+			return nil, uc.NewErrInvalidParameter("srcs", errors.New("a template has no name"))
+		}
+
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	tmpls := make(map[string]*Template, len(srcs))
+
+	for _, name := range names {
+		t, err := parse(name, srcs[name], opts)
+		if err != nil {
+			return nil, err
+		}
+
+		tmpls[name] = t
+	}
+
+	defs, err := link(tmpls, srcs)
+	if err != nil {
+		return nil, err
+	}
+
+	set := &TemplateSet{
+		templates: tmpls,
+	}
+
+	if len(names) == 0 {
+		return set, nil
+	}
+
+	first := tmpls[names[0]]
+
+	for name, def := range defs {
+		if _, ok := tmpls[name]; ok {
+			continue
+		}
+
+		root := NewNode(SourceNode, "")
+
+		for _, node := range def_body(def) {
+			add_child(root, copy_node(node))
+		}
+
+		tmpls[name] = first.with_root(root)
+	}
+
+	return set, nil
+}
+
+// Lookup returns the template with the given name.
+//
+// Parameters:
+//   - name: The name of a template of the set or of a sub-template defined by one.
+//
+// Returns:
+//   - *Template: The template. Nil if there is none.
+//   - bool: True if the template exists, false otherwise.
+func (s *TemplateSet) Lookup(name string) (*Template, bool) {
+	t, ok := s.templates[name]
+	return t, ok
+}
+
+// Names returns the names of the templates of the set; including those of the
+// sub-templates they define.
+//
+// Returns:
+//   - []string: The names; sorted.
+func (s *TemplateSet) Names() []string {
+	names := make([]string, 0, len(s.templates))
+
+	for name := range s.templates {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	return names
+}
+
+// Execute applies the data to the template with the given name and writes the result.
+//
+// Parameters:
+//   - w: The writer.
+//   - name: The name of the template.
+//   - data: The data to apply.
+//   - opts: The options of the execution. (e.g., OnMissing)
+//
+// Returns:
+//   - error: An error if the template does not exist or if it could not be executed.
+func (s *TemplateSet) Execute(w io.Writer, name string, data any, opts ...ExecOption) error {
+	t, ok := s.templates[name]
+	if !ok {
+		return fmt.Errorf("template %q is not defined", name)
+	}
+
+	return t.Execute(w, data, opts...)
+}
+
+// link is a helper function that gathers the sub-templates of the given templates and
+// checks that every sub-template they execute is defined and never executes itself. The
+// templates then share the table of the sub-templates.
+//
+// Parameters:
+//   - tmpls: The templates, by name. A template with a name is a sub-template of that
+//     name for the others.
+//   - srcs: The template strings, by name; used in error messages.
+//
+// Returns:
+//   - map[string]*Node: The sub-templates, by name.
+//   - error: An error if the sub-templates are invalid.
+func link(tmpls map[string]*Template, srcs map[string]string) (map[string]*Node, error) {
+	names := make([]string, 0, len(tmpls))
+
+	for name := range tmpls {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	table := new_def_table()
+
+	for _, name := range names {
+		if name != "" {
+			table.defs[name] = tmpls[name].root
+			table.owners[name] = name
+		}
+	}
+
+	for _, name := range names {
+		err := table.add(name, tmpls[name].root.Children)
+		if err != nil {
+			utpx.SetSource(err, name, srcs[name])
+
+			return nil, fmt.Errorf("invalid template: %w", err)
+		}
+	}
+
+	for _, name := range names {
+		err := table.check_refs(tmpls[name].root.Children)
+		if err != nil {
+			utpx.SetSource(err, name, srcs[name])
+
+			return nil, fmt.Errorf("invalid template: %w", err)
+		}
+	}
+
+	owner, err := table.check_cycles()
+	if err != nil {
+		utpx.SetSource(err, owner, srcs[owner])
+
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+
+	for _, t := range tmpls {
+		t.defs = table.defs
+	}
+
+	return table.defs, nil
+}
+
+// def_body is a helper function that returns the body of a sub-template.
+//
+// Parameters:
+//   - def: The source, define or block node of the sub-template.
+//
+// Returns:
+//   - []*Node: The nodes of the body.
+func def_body(def *Node) []*Node {
+	if def.Kind == SourceNode {
+		return def.Children
+	}
+
+	return def.Children[0].Children
+}
+
+// def_table is the table of the sub-templates of a set of templates.
+type def_table struct {
+	// defs are the sub-templates, by name; that is, the source, define or block nodes
+	// whose body they are.
+	defs map[string]*Node
+
+	// owners are the names of the templates the sub-templates are written in, by name.
+	owners map[string]string
+}
+
+// new_def_table creates a new table of sub-templates.
+//
+// Returns:
+//   - *def_table: The table. Never returns nil.
+func new_def_table() *def_table {
+	return &def_table{
+		defs:   make(map[string]*Node),
+		owners: make(map[string]string),
+	}
+}
+
+// add adds the sub-templates defined by the given nodes. A define node overrides a block
+// node of the same name; whatever the order they are found in.
+//
+// Parameters:
+//   - owner: The name of the template the nodes are written in.
+//   - nodes: The nodes.
+//
+// Returns:
+//   - error: An *utpx.ErrSyntax if a sub-template is defined twice.
+func (dt *def_table) add(owner string, nodes []*Node) error {
+	for _, node := range nodes {
+		if node.Kind == DefineNode || node.Kind == BlockNode {
+			prev, ok := dt.defs[node.Data]
+
+			switch {
+			case !ok, prev.Kind == BlockNode && node.Kind == DefineNode:
+				dt.defs[node.Data] = node
+				dt.owners[node.Data] = owner
+			case prev.Kind == DefineNode && node.Kind == BlockNode:
+				// The block is overridden.
+			default:
+				return utpx.NewErrSyntax(0, node.Pos, fmt.Errorf("template %q is already defined", node.Data))
+			}
+		}
+
+		err := dt.add(owner, node.Children)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// check_refs checks that every sub-template executed by the given nodes is defined.
+//
+// Parameters:
+//   - nodes: The nodes.
+//
+// Returns:
+//   - error: An *utpx.ErrSyntax if a sub-template is not defined.
+func (dt *def_table) check_refs(nodes []*Node) error {
+	for _, node := range nodes {
+		if node.Kind == TemplateNode {
+			_, ok := dt.defs[node.Data]
+			if !ok {
+				return utpx.NewErrSyntax(0, node.Pos, fmt.Errorf("template %q is not defined", node.Data))
+			}
+		}
+
+		err := dt.check_refs(node.Children)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// check_cycles checks that no sub-template executes itself; directly or not.
+//
+// Returns:
+//   - string: The name of the template the cycle is found in. Empty if there is none.
+//   - error: An *utpx.ErrSyntax, at the action that closes the cycle, if there is one.
+func (dt *def_table) check_cycles() (string, error) {
+	names := make([]string, 0, len(dt.defs))
+
+	for name := range dt.defs {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	// done is false while the sub-template is visited and true once it is.
+	done := make(map[string]bool, len(names))
+
+	for _, name := range names {
+		owner, err := dt.visit(name, done, nil)
+		if err != nil {
+			return owner, err
+		}
+	}
+
+	return "", nil
+}
+
+// visit is a helper function that visits a sub-template, and the ones it executes, depth
+// first.
+//
+// Parameters:
+//   - name: The name of the sub-template.
+//   - done: The visited sub-templates; false while they are visited.
+//   - path: The names of the sub-templates that are visited; the outermost first.
+//
+// Returns:
+//   - string: The name of the template the cycle is found in. Empty if there is none.
+//   - error: An *utpx.ErrSyntax, at the action that closes the cycle, if there is one.
+func (dt *def_table) visit(name string, done map[string]bool, path []string) (string, error) {
+	if _, ok := done[name]; ok {
+		return "", nil
+	}
+
+	done[name] = false
+	path = append(path, name)
+
+	for _, ref := range executed(def_body(dt.defs[name])) {
+		is_done, ok := done[ref.Data]
+
+		switch {
+		case !ok:
+			owner, err := dt.visit(ref.Data, done, path)
+			if err != nil {
+				return owner, err
+			}
+		case !is_done:
+			idx := slices.Index(path, ref.Data)
+			cycle := strings.Join(append(slices.Clone(path[idx:]), ref.Data), " -> ")
+
+			return dt.owners[name], utpx.NewErrSyntax(0, ref.Pos, fmt.Errorf("recursive inclusion of template %q: %s", ref.Data, cycle))
+		}
+	}
+
+	done[name] = true
+
+	return "", nil
+}
+
+// executed is a helper function that returns the template and block nodes executed by
+// the given nodes. The bodies of the define and block nodes are not searched since they
+// are sub-templates of their own.
+//
+// Parameters:
+//   - nodes: The nodes.
+//
+// Returns:
+//   - []*Node: The template and block nodes; in order.
+func executed(nodes []*Node) []*Node {
+	var result []*Node
+
+	for _, node := range nodes {
+		switch node.Kind {
+		case TemplateNode, BlockNode:
+			result = append(result, node)
+		case DefineNode:
+			// The body is a sub-template of its own.
+		default:
+			result = append(result, executed(node.Children)...)
+		}
+	}
+
+	return result
+}
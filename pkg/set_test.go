@@ -0,0 +1,110 @@
+package pkg
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestTemplateSet(t *testing.T) {
+	srcs := map[string]string{
+		"header": "// Code generated. DO NOT EDIT.\npackage {{ .Pkg }}\n{{- define \"imports\" }}\nimport \"fmt\"{{ end }}\n",
+		"stack":  "{{ template \"header\" . }}type {{ .Name }} struct{}{{ block \"doc\" .Name }}\n// {{ . }} is a stack.{{ end }}",
+		"queue":  "{{ template \"header\" . }}{{ template \"imports\" }}\ntype {{ .Name }} struct{}",
+	}
+
+	set, err := NewTemplateSet(srcs)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	names := set.Names()
+	if !slices.Equal(names, []string{"doc", "header", "imports", "queue", "stack"}) {
+		t.Errorf("unexpected names %v", names)
+	}
+
+	tests := []struct {
+		name     string
+		data     any
+		expected string
+	}{
+		{"stack", map[string]string{"Pkg": "ds", "Name": "Stack"}, "// Code generated. DO NOT EDIT.\npackage ds\ntype Stack struct{}\n// Stack is a stack."},
+		{"queue", map[string]string{"Pkg": "ds", "Name": "Queue"}, "// Code generated. DO NOT EDIT.\npackage ds\n\nimport \"fmt\"\ntype Queue struct{}"},
+		{"doc", "Stack", "\n// Stack is a stack."},
+	}
+
+	for _, test := range tests {
+		var builder strings.Builder
+
+		err := set.Execute(&builder, test.name, test.data)
+		if err != nil {
+			t.Fatalf("expected no error for %q, got %s", test.name, err.Error())
+		}
+
+		if builder.String() != test.expected {
+			t.Errorf("expected %q, got %q", test.expected, builder.String())
+		}
+	}
+
+	var builder strings.Builder
+
+	err = set.Execute(&builder, "missing", nil)
+	if err == nil {
+		t.Errorf("expected error for an undefined template, got nil")
+	}
+
+	_, ok := set.Lookup("imports")
+	if !ok {
+		t.Errorf("expected the imports sub-template to be found")
+	}
+}
+
+func TestTemplateSetOverride(t *testing.T) {
+	srcs := map[string]string{
+		"base":  "<{{ block \"body\" . }}default{{ end }}>",
+		"child": "{{ define \"body\" }}{{ .Name }}{{ end }}",
+	}
+
+	set, err := NewTemplateSet(srcs)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	var builder strings.Builder
+
+	err = set.Execute(&builder, "base", struct{ Name string }{"stack"})
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	if builder.String() != "<stack>" {
+		t.Errorf("expected %q, got %q", "<stack>", builder.String())
+	}
+}
+
+func TestTemplateSetErrors(t *testing.T) {
+	tests := []struct {
+		srcs     map[string]string
+		expected string
+	}{
+		{map[string]string{"a": "{{ template \"b\" . }}", "b": "x{{ template \"c\" }}", "c": "{{ if .A }}{{ template \"a\" . }}{{ end }}"},
+			"c:1:12: recursive inclusion of template \"a\": a -> b -> c -> a"},
+		{map[string]string{"a": "{{ block \"x\" . }}{{ template \"x\" }}{{ end }}"}, "a:1:18: recursive inclusion of template \"x\": x -> x"},
+		{map[string]string{"a": "\n{{ template \"nope\" . }}"}, "a:2:1: template \"nope\" is not defined"},
+		{map[string]string{"a": "{{ define \"x\" }}{{ end }}", "b": "{{ define \"x\" }}{{ end }}"}, "b:1:1: template \"x\" is already defined"},
+		{map[string]string{"a": "{{ define \"b\" }}{{ end }}", "b": "b"}, "a:1:1: template \"b\" is already defined"},
+		{map[string]string{"a": "{{ .A"}, "a:1:6: unexpected end of input"},
+		{map[string]string{"": "a"}, "a template has no name"},
+	}
+
+	for _, test := range tests {
+		_, err := NewTemplateSet(test.srcs)
+		if err == nil {
+			t.Fatalf("expected error for %v, got nil", test.srcs)
+		}
+
+		if !strings.Contains(err.Error(), test.expected) {
+			t.Errorf("expected %q to contain %q", err.Error(), test.expected)
+		}
+	}
+}
@@ -0,0 +1,1259 @@
+package pkg
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	prx "github.com/PlayerR9/go_generator/pkg/parsing"
+	utpx "github.com/PlayerR9/go_generator/util/parsing"
+	uc "github.com/PlayerR9/lib_units/common"
+)
+
+// Template is a template.
+type Template struct {
+	// root is the root node of the AST.
+	root *Node
+
+	// funcs are the functions the template can call.
+	funcs FuncMap
+
+	// sep is the separator between the elements of an array or a slice.
+	sep string
+
+	// nil_text is the text of a nil value.
+	nil_text string
+
+	// tag is the key of the struct tags that name the fields. Empty if fields are only
+	// found by their name.
+	tag string
+
+	// open is the delimiter that opens an action.
+	open string
+
+	// close is the delimiter that closes an action.
+	close string
+
+	// defs are the sub-templates the template can execute, by name; that is, the source,
+	// define or block nodes whose body they are. Shared by the templates of a set.
+	defs map[string]*Node
+}
+
+// NewTemplate creates a new template.
+//
+// Parameters:
+//   - str: The template string.
+//   - opts: The options of the template. (e.g., WithFuncs) The functions of DefaultFuncs
+//     are always installed.
+//
+// Returns:
+//   - *Template: The template. Nil if an error occurs.
+//   - error: An error if the template is invalid; including if it executes a sub-template
+//     that it does not define or that executes itself.
+func NewTemplate(str string, opts ...Option) (*Template, error) {
+	t, err := parse("", str, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = link(map[string]*Template{"": t}, map[string]string{"": str})
+	if err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// parse is a helper function that parses a template. The sub-templates it executes are
+// not resolved yet.
+//
+// Parameters:
+//   - name: The name of the template; used in error messages. Empty if it has none.
+//   - str: The template string.
+//   - opts: The options of the template.
+//
+// Returns:
+//   - *Template: The template. Nil if an error occurs.
+//   - error: An error if the template is invalid.
+func parse(name, str string, opts []Option) (*Template, error) {
+	t := &Template{
+		funcs: DefaultFuncs(),
+		sep:   ", ",
+	}
+
+	for _, opt := range opts {
+		if opt != nil {
+			opt(t)
+		}
+	}
+
+	funcs, err := new_funcs(t.funcs)
+	if err != nil {
+		return nil, fmt.Errorf("invalid functions: %w", err)
+	}
+
+	t.funcs = funcs
+
+	t.open = cmp.Or(t.open, prx.DefaultOpen)
+	t.close = cmp.Or(t.close, prx.DefaultClose)
+
+	if str == "" {
+		return nil, fmt.Errorf("invalid template: %w", uc.NewErrInvalidParameter("str", uc.NewErrEmpty(str)))
+	}
+
+	root, err := prx.ParseReaderDelims(strings.NewReader(str), t.open, t.close)
+	if err != nil {
+		utpx.SetSource(err, name, str)
+
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+
+	node, err := to_ast(root, t.funcs, t.open, t.close)
+	if err != nil {
+		utpx.SetSource(err, name, str)
+
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+
+	t.root = node
+
+	return t, nil
+}
+
+// Apply applies the data to the template in place; variables are replaced by their text
+// and blocks by the branches that were taken. Unlike Execute, it modifies the template and
+// so it must not be called concurrently with any other method.
+//
+// Parameters:
+//   - data: The data to apply.
+//   - opts: The options of the execution. (e.g., OnMissing)
+//
+// Returns:
+//   - error: An error if the data could not be applied.
+func (t *Template) Apply(data any, opts ...ExecOption) error {
+	children, err := t.evaluate(data, opts)
+	if err != nil {
+		return err
+	}
+
+	// The root is replaced, rather than modified, since the other templates of a set may
+	// execute it as a sub-template.
+	root := NewNode(SourceNode, "")
+
+	for _, child := range children {
+		add_child(root, child)
+	}
+
+	t.root = root
+
+	return nil
+}
+
+// evaluate is a helper function that applies the data to the template without modifying it.
+//
+// Parameters:
+//   - data: The data to apply.
+//   - opts: The options of the execution.
+//
+// Returns:
+//   - []*Node: The new nodes of the template once applied; text nodes unless actions were
+//     kept.
+//   - error: An error if the data could not be applied.
+func (t *Template) evaluate(data any, opts []ExecOption) ([]*Node, error) {
+	value, err := data_value(data)
+	if err != nil {
+		return nil, err
+	}
+
+	sc := new_scope(value, t.tag)
+
+	for _, opt := range opts {
+		if opt != nil {
+			opt(sc)
+		}
+	}
+
+	return t.apply(t.root.Children, sc)
+}
+
+// data_value is a helper function that returns the value of the data of a template.
+//
+// Parameters:
+//   - data: The data.
+//
+// Returns:
+//   - reflect.Value: The value of the data.
+//   - error: An error if the data is invalid.
+func data_value(data any) (reflect.Value, error) {
+	if data == nil {
+		return reflect.Value{}, uc.NewErrNilParameter("data")
+	}
+
+	value := reflect.ValueOf(data)
+
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return reflect.Value{}, uc.NewErrNilParameter("data")
+		}
+
+		value = value.Elem()
+	}
+
+	return value, nil
+}
+
+// apply is a helper function that applies the data to the given nodes.
+//
+// Parameters:
+//   - nodes: The nodes to apply the data to.
+//   - sc: The scope of the nodes.
+//
+// Returns:
+//   - []*Node: The text nodes once applied. Variables are replaced by their text, blocks
+//     by the nodes of the branches that were taken, sub-templates by their nodes once
+//     applied and comments and definitions are dropped. The given nodes are not modified.
+//   - error: An error if the data could not be applied.
+func (t *Template) apply(nodes []*Node, sc *scope) ([]*Node, error) {
+	uc.AssertParam("sc", sc != nil, errors.New("sc is nil"))
+
+	var result []*Node
+
+	for _, node := range nodes {
+		uc.AssertParam("node", node != nil, errors.New("node is nil"))
+
+		switch node.Kind {
+		case VariableNode:
+			value, err := t.eval_pipeline(node.Pipe, sc)
+			if sc.keeps(err) {
+				result = append(result, copy_node(node))
+
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+
+			text, err := t.format_value(value)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", node.Data, err)
+			}
+
+			result = append(result, NewNode(TextNode, text))
+		case AssignNode:
+			value, err := t.eval_pipeline(node.Pipe, sc)
+			if sc.keeps(err) {
+				// The actions that use the variable are kept as well.
+				delete(sc.vars, node.Vars[0])
+
+				result = append(result, copy_node(node))
+
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+
+			sc.vars[node.Vars[0]] = value
+		case TextNode:
+			result = append(result, NewNode(TextNode, node.Data))
+		case CommentNode, DefineNode:
+			// Comments and definitions produce no output.
+		case TemplateNode, BlockNode:
+			sub_nodes, err := t.apply_template(node, sc)
+			if err != nil {
+				return nil, err
+			}
+
+			result = append(result, sub_nodes...)
+		case IfNode:
+			branch, err := t.take_branch(node, sc)
+			if sc.keeps(err) {
+				result = append(result, copy_node(node))
+
+				continue
+			} else if err != nil {
+				return nil, err
+			} else if branch == nil {
+				continue
+			}
+
+			sub_nodes, err := t.apply(branch.Children, sc.with(sc.dot, nil, nil))
+			if err != nil {
+				return nil, err
+			}
+
+			result = append(result, sub_nodes...)
+		case RangeNode:
+			sub_nodes, err := t.apply_range(node, sc)
+			if err != nil {
+				return nil, err
+			}
+
+			result = append(result, sub_nodes...)
+		default:
+			return nil, fmt.Errorf("invalid node: %s", node.Kind.String())
+		}
+	}
+
+	return result, nil
+}
+
+// apply_range is a helper function that applies the data to a range node. The body is
+// applied once per element of the collection with the dot bound to the element; or the
+// else branch, if any, when the collection is empty. Maps are iterated in the order of
+// their sorted keys.
+//
+// Parameters:
+//   - node: The range node.
+//   - sc: The scope of the node.
+//
+// Returns:
+//   - []*Node: The text nodes once applied.
+//   - error: An error if the data could not be applied.
+func (t *Template) apply_range(node *Node, sc *scope) ([]*Node, error) {
+	uc.AssertParam("node", len(node.Children) > 0, errors.New("range node has no body"))
+
+	value, err := t.eval_pipeline(node.Pipe, sc)
+	if sc.keeps(err) {
+		return []*Node{copy_node(node)}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	keys, values, err := range_over(value)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", node.Data, err)
+	}
+
+	if len(values) == 0 {
+		if len(node.Children) < 2 {
+			return nil, nil
+		}
+
+		return t.apply(node.Children[1].Children, sc.with(sc.dot, nil, nil))
+	}
+
+	var result []*Node
+
+	for i, elem := range values {
+		var sub_sc *scope
+
+		switch len(node.Vars) {
+		case 0:
+			sub_sc = sc.with(elem, nil, nil)
+		case 1:
+			sub_sc = sc.with(elem, node.Vars, []reflect.Value{elem})
+		default:
+			sub_sc = sc.with(elem, node.Vars, []reflect.Value{keys[i], elem})
+		}
+
+		sub_nodes, err := t.apply(node.Children[0].Children, sub_sc)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, sub_nodes...)
+	}
+
+	return result, nil
+}
+
+// apply_template is a helper function that applies the data to a template or a block
+// node. The sub-template is applied with the value of the pipeline as the dot.
+//
+// Parameters:
+//   - node: The template or block node.
+//   - sc: The scope of the node.
+//
+// Returns:
+//   - []*Node: The text nodes once applied.
+//   - error: An error if the data could not be applied.
+func (t *Template) apply_template(node *Node, sc *scope) ([]*Node, error) {
+	dot, err := t.template_dot(node, sc)
+	if sc.keeps(err) {
+		return []*Node{copy_node(node)}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	def, ok := t.defs[node.Data]
+	if !ok {
+		return nil, fmt.Errorf("template %q is not defined", node.Data)
+	}
+
+	return t.apply(def_body(def), sc.sub(dot))
+}
+
+// template_dot is a helper function that evaluates the dot of the sub-template executed
+// by a template or a block node.
+//
+// Parameters:
+//   - node: The template or block node.
+//   - sc: The scope of the node.
+//
+// Returns:
+//   - reflect.Value: The value of the pipeline of the node. Invalid if the node has none.
+//   - error: An error if the pipeline could not be evaluated.
+func (t *Template) template_dot(node *Node, sc *scope) (reflect.Value, error) {
+	if node.Pipe == nil {
+		return reflect.Value{}, nil
+	}
+
+	return t.eval_pipeline(node.Pipe, sc)
+}
+
+// range_over is a helper function that returns the elements of a collection.
+//
+// Parameters:
+//   - value: The collection. Either an array, a slice or a map; nil collections are empty.
+//
+// Returns:
+//   - []reflect.Value: The indices, or the sorted keys of a map.
+//   - []reflect.Value: The elements; in the same order as the indices.
+//   - error: An error if the value cannot be ranged over.
+func range_over(value reflect.Value) ([]reflect.Value, []reflect.Value, error) {
+	elem, ok := indirect(value)
+	if !ok || !elem.IsValid() {
+		return nil, nil, nil
+	}
+
+	switch elem.Kind() {
+	case reflect.Array, reflect.Slice:
+		keys := make([]reflect.Value, 0, elem.Len())
+		values := make([]reflect.Value, 0, elem.Len())
+
+		for i := 0; i < elem.Len(); i++ {
+			keys = append(keys, reflect.ValueOf(i))
+			values = append(values, elem.Index(i))
+		}
+
+		return keys, values, nil
+	case reflect.Map:
+		keys := elem.MapKeys()
+
+		slices.SortFunc(keys, compare_keys)
+
+		values := make([]reflect.Value, 0, len(keys))
+
+		for _, key := range keys {
+			values = append(values, elem.MapIndex(key))
+		}
+
+		return keys, values, nil
+	default:
+		return nil, nil, fmt.Errorf("cannot range over %s", elem.Type().String())
+	}
+}
+
+// compare_keys is a helper function that compares two keys of a map. Keys of the basic
+// kinds are compared by value; the others by their formatted value.
+//
+// Parameters:
+//   - a: The first key.
+//   - b: The second key.
+//
+// Returns:
+//   - int: A negative number if a < b, a positive number if a > b, 0 otherwise.
+func compare_keys(a, b reflect.Value) int {
+	switch a.Kind() {
+	case reflect.String:
+		return cmp.Compare(a.String(), b.String())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(a.Int(), b.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return cmp.Compare(a.Uint(), b.Uint())
+	case reflect.Float32, reflect.Float64:
+		return cmp.Compare(a.Float(), b.Float())
+	case reflect.Bool:
+		if a.Bool() == b.Bool() {
+			return 0
+		} else if a.Bool() {
+			return 1
+		}
+
+		return -1
+	default:
+		return strings.Compare(fmt.Sprint(a.Interface()), fmt.Sprint(b.Interface()))
+	}
+}
+
+// take_branch is a helper function that returns the branch of an if node whose condition
+// holds.
+//
+// Parameters:
+//   - node: The if node.
+//   - sc: The scope of the node.
+//
+// Returns:
+//   - *Node: The branch. Nil if no condition holds and there is no else branch.
+//   - error: An error if a condition could not be evaluated.
+func (t *Template) take_branch(node *Node, sc *scope) (*Node, error) {
+	for _, branch := range node.Children {
+		if branch.Pipe == nil {
+			return branch, nil
+		}
+
+		cond, err := t.eval_pipeline(branch.Pipe, sc)
+		if err != nil {
+			return nil, err
+		}
+
+		if is_true(cond) {
+			return branch, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// eval_pipeline is a helper function that evaluates a pipeline.
+//
+// Parameters:
+//   - pipe: The pipeline node.
+//   - sc: The scope of the pipeline.
+//
+// Returns:
+//   - reflect.Value: The value of the last stage. Invalid if it is nil.
+//   - error: An error if a stage could not be evaluated.
+func (t *Template) eval_pipeline(pipe *Node, sc *scope) (reflect.Value, error) {
+	uc.AssertParam("pipe", pipe != nil && pipe.Kind == PipelineNode, errors.New("pipe must be a pipeline node"))
+
+	var value reflect.Value
+
+	for i, stage := range pipe.Children {
+		var err error
+
+		if i == 0 {
+			value, err = t.eval_operand(stage, sc, nil)
+		} else {
+			value, err = t.eval_operand(stage, sc, &value)
+		}
+
+		if err == nil {
+			continue
+		} else if i+1 < len(pipe.Children) && sc.defaults(stage, pipe.Children[i+1], err) {
+			value = reflect.Value{}
+
+			continue
+		}
+
+		return reflect.Value{}, err
+	}
+
+	return value, nil
+}
+
+// eval_operand is a helper function that evaluates a stage of a pipeline or an argument of
+// a call.
+//
+// Parameters:
+//   - node: The call, field or literal node.
+//   - sc: The scope of the node.
+//   - piped: The value of the previous stage, if any. It is given to the call as its last
+//     argument.
+//
+// Returns:
+//   - reflect.Value: The value of the node. Invalid if it is nil.
+//   - error: An error if the node could not be evaluated.
+func (t *Template) eval_operand(node *Node, sc *scope, piped *reflect.Value) (reflect.Value, error) {
+	switch node.Kind {
+	case FieldNode:
+		value, err := sc.resolve(node.Data)
+		if err == nil {
+			return value, nil
+		}
+
+		field_err, ok := err.(*ErrField)
+		if !ok {
+			return reflect.Value{}, err
+		}
+
+		field_err.Pos = node.Pos
+
+		if sc.missing == MissingZero {
+			return reflect.Value{}, nil
+		}
+
+		return reflect.Value{}, field_err
+	case LiteralNode:
+		return literal_value(node.Data)
+	case CallNode:
+		fn, ok := t.funcs[node.Data]
+		if !ok {
+			return reflect.Value{}, NewErrCall(node.Data, errors.New("function is not defined"))
+		}
+
+		args := make([]reflect.Value, 0, len(node.Children)+1)
+
+		for _, child := range node.Children {
+			arg, err := t.eval_operand(child, sc, nil)
+			if err != nil && !sc.defaults(child, node, err) {
+				return reflect.Value{}, err
+			}
+
+			args = append(args, arg)
+		}
+
+		if piped != nil {
+			args = append(args, *piped)
+		}
+
+		return call_func(node.Data, reflect.ValueOf(fn), args)
+	default:
+		return reflect.Value{}, fmt.Errorf("invalid node: %s", node.Kind.String())
+	}
+}
+
+// literal_value is a helper function that returns the value of a literal.
+//
+// Parameters:
+//   - text: The literal as written in the template. (e.g., "\"a\"", "42" or "true")
+//
+// Returns:
+//   - reflect.Value: The value. A string, an int, a float64 or a bool.
+//   - error: An error if the literal is invalid.
+func literal_value(text string) (reflect.Value, error) {
+	switch {
+	case text == "true" || text == "false":
+		return reflect.ValueOf(text == "true"), nil
+	case strings.HasPrefix(text, "\"") || strings.HasPrefix(text, "`"):
+		str, err := strconv.Unquote(text)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("invalid string %s: %w", text, err)
+		}
+
+		return reflect.ValueOf(str), nil
+	case strings.Contains(text, "."):
+		f, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("invalid number %s: %w", text, err)
+		}
+
+		return reflect.ValueOf(f), nil
+	default:
+		i, err := strconv.Atoi(text)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("invalid number %s: %w", text, err)
+		}
+
+		return reflect.ValueOf(i), nil
+	}
+}
+
+// format_value is a helper function that returns the text of a value. Values that
+// implement fmt.Stringer, or else fmt.GoStringer, are written with that method; the others
+// according to their kind. The elements of arrays and slices are written one after the
+// other with the separator of the template.
+//
+// Parameters:
+//   - value: The value.
+//
+// Returns:
+//   - string: The text of the value. The nil text of the template if the value is nil and
+//     the empty string if there is no value; that is, for a missing field under
+//     MissingZero.
+//   - error: An *ErrFormat if the value cannot be written.
+func (t *Template) format_value(value reflect.Value) (string, error) {
+	if !value.IsValid() {
+		return "", nil
+	}
+
+	for {
+		is_ref := value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface
+
+		if is_ref && value.IsNil() {
+			return t.nil_text, nil
+		}
+
+		if value.CanInterface() {
+			switch v := value.Interface().(type) {
+			case fmt.Stringer:
+				return v.String(), nil
+			case fmt.GoStringer:
+				return v.GoString(), nil
+			}
+		}
+
+		if !is_ref {
+			break
+		}
+
+		value = value.Elem()
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		return value.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(value.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(value.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(value.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(value.Float(), 'g', -1, value.Type().Bits()), nil
+	case reflect.Complex64, reflect.Complex128:
+		return strconv.FormatComplex(value.Complex(), 'g', -1, value.Type().Bits()), nil
+	case reflect.Array, reflect.Slice:
+		elems := make([]string, 0, value.Len())
+
+		for i := 0; i < value.Len(); i++ {
+			text, err := t.format_value(value.Index(i))
+			if err != nil {
+				return "", err
+			}
+
+			elems = append(elems, text)
+		}
+
+		return strings.Join(elems, t.sep), nil
+	default:
+		return "", NewErrFormat(value.Type())
+	}
+}
+
+// is_true is a helper function that checks whether a value is true as a condition.
+//
+// Like in Go templates, a value is false if it is the zero value of its type or if it is
+// an empty array, slice, map or string. Structs are always true.
+//
+// Parameters:
+//   - value: The value.
+//
+// Returns:
+//   - bool: True if the value is true, false otherwise.
+func is_true(value reflect.Value) bool {
+	if !value.IsValid() {
+		return false
+	}
+
+	value = unwrap(value)
+
+	switch value.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return value.Len() > 0
+	case reflect.Bool:
+		return value.Bool()
+	case reflect.Complex64, reflect.Complex128:
+		return value.Complex() != 0
+	case reflect.Chan, reflect.Func, reflect.Pointer, reflect.Interface:
+		return !value.IsNil()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return value.Int() != 0
+	case reflect.Float32, reflect.Float64:
+		return value.Float() != 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return value.Uint() != 0
+	case reflect.Struct:
+		return true
+	}
+
+	return false
+}
+
+// unwrap is a helper function that returns the value held by an interface; so that, for
+// example, a value of a map[string]any has the kind of what it holds.
+//
+// Parameters:
+//   - value: The value.
+//
+// Returns:
+//   - reflect.Value: The value held. The value itself if it is not an interface or if it
+//     is a nil one.
+func unwrap(value reflect.Value) reflect.Value {
+	for value.Kind() == reflect.Interface && !value.IsNil() {
+		value = value.Elem()
+	}
+
+	return value
+}
+
+// indirect is a helper function that dereferences pointers and interfaces until it reaches
+// a concrete value.
+//
+// Parameters:
+//   - value: The value to dereference.
+//
+// Returns:
+//   - reflect.Value: The concrete value.
+//   - bool: False if a nil pointer or interface was found, true otherwise.
+func indirect(value reflect.Value) (reflect.Value, bool) {
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return value, false
+		}
+
+		value = value.Elem()
+	}
+
+	return value, true
+}
+
+// scope is the scope in which the nodes of a template are applied.
+type scope struct {
+	// dot is the value of the dot.
+	dot reflect.Value
+
+	// vars are the values of the variables; including $, the data of the template.
+	vars map[string]reflect.Value
+
+	// tag is the key of the struct tags that name the fields. Empty if fields are only
+	// found by their name.
+	tag string
+
+	// missing is what to do with the field paths that cannot be resolved.
+	missing MissingPolicy
+}
+
+// new_scope creates the scope of a template.
+//
+// Parameters:
+//   - data: The data of the template.
+//   - tag: The key of the struct tags that name the fields. (e.g., "json")
+//
+// Returns:
+//   - *scope: The scope. Never returns nil.
+func new_scope(data reflect.Value, tag string) *scope {
+	return &scope{
+		dot: data,
+		vars: map[string]reflect.Value{
+			"$": data,
+		},
+		tag: tag,
+	}
+}
+
+// with creates a nested scope.
+//
+// Parameters:
+//   - dot: The value of the dot.
+//   - names: The names of the declared variables.
+//   - values: The values of the declared variables; in the same order as names.
+//
+// Returns:
+//   - *scope: The nested scope. Never returns nil.
+func (s *scope) with(dot reflect.Value, names []string, values []reflect.Value) *scope {
+	vars := make(map[string]reflect.Value, len(s.vars)+len(names))
+
+	for name, value := range s.vars {
+		vars[name] = value
+	}
+
+	for i, name := range names {
+		vars[name] = values[i]
+	}
+
+	return &scope{
+		dot:     dot,
+		vars:    vars,
+		tag:     s.tag,
+		missing: s.missing,
+	}
+}
+
+// sub creates the scope of a sub-template. Only $ is in scope; bound to the dot.
+//
+// Parameters:
+//   - dot: The value of the dot.
+//
+// Returns:
+//   - *scope: The scope of the sub-template. Never returns nil.
+func (s *scope) sub(dot reflect.Value) *scope {
+	sc := new_scope(dot, s.tag)
+	sc.missing = s.missing
+
+	return sc
+}
+
+// keeps checks whether an action must be kept as it is written because one of its fields
+// cannot be resolved; that is, with the MissingKeep policy.
+//
+// Parameters:
+//   - err: The error of the evaluation of the action.
+//
+// Returns:
+//   - bool: True if the action must be kept, false otherwise.
+func (s *scope) keeps(err error) bool {
+	if s.missing != MissingKeep {
+		return false
+	}
+
+	var field_err *ErrField
+
+	return errors.As(err, &field_err)
+}
+
+// defaults checks whether a field that cannot be resolved is given to the default function
+// and so is nil; that is, with the MissingDefault policy.
+//
+// Parameters:
+//   - operand: The operand that could not be evaluated.
+//   - call: The stage or the call the operand is given to.
+//   - err: The error of the evaluation of the operand.
+//
+// Returns:
+//   - bool: True if the operand is nil, false otherwise.
+func (s *scope) defaults(operand, call *Node, err error) bool {
+	if s.missing != MissingDefault || operand.Kind != FieldNode {
+		return false
+	} else if call.Kind != CallNode || call.Data != "default" {
+		return false
+	}
+
+	_, ok := err.(*ErrField)
+
+	return ok
+}
+
+// resolve resolves a field path. The path starts either at the dot or at a variable; each
+// following segment is resolved by the field method. Pointers and interfaces are followed
+// along the way.
+//
+// Parameters:
+//   - path: The field path. (e.g., ".", ".Type.Name" or "$v.Name")
+//
+// Returns:
+//   - reflect.Value: The value the path points to.
+//   - error: An error of type *ErrField if a segment cannot be resolved, or of type *ErrCall
+//     if a method fails.
+func (s *scope) resolve(path string) (reflect.Value, error) {
+	head, rest, _ := strings.Cut(path, ".")
+
+	var value reflect.Value
+
+	if head == "" {
+		value = s.dot
+	} else {
+		var ok bool
+
+		value, ok = s.vars[head]
+		if !ok {
+			return reflect.Value{}, NewErrField(path, head, errors.New("undefined variable"))
+		}
+	}
+
+	if rest == "" {
+		return value, nil
+	}
+
+	for _, name := range strings.Split(rest, ".") {
+		var err error
+
+		value, err = s.field(path, name, value)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+	}
+
+	return value, nil
+}
+
+// field resolves a segment of a field path. In order, the segment is either:
+//   - a method without arguments of the value, which is called;
+//   - a field of a struct, promoted fields included, whose tag names it (if the scope has
+//     a tag key) or whose name is the segment;
+//   - a key of a map with string keys.
+//
+// Parameters:
+//   - path: The full field path; for errors.
+//   - name: The segment.
+//   - value: The value the segment belongs to.
+//
+// Returns:
+//   - reflect.Value: The value of the segment.
+//   - error: An error of type *ErrField if the segment cannot be resolved, or of type
+//     *ErrCall if the method fails.
+func (s *scope) field(path, name string, value reflect.Value) (reflect.Value, error) {
+	elem, ok := indirect(value)
+	if !ok {
+		return reflect.Value{}, NewErrField(path, name, fmt.Errorf("nil %s", value.Type().String()))
+	} else if !elem.IsValid() {
+		// e.g., the dot of a sub-template executed without a pipeline.
+		return reflect.Value{}, NewErrField(path, name, errors.New("nil data"))
+	}
+
+	method := elem.MethodByName(name)
+	if !method.IsValid() && elem.CanAddr() {
+		method = elem.Addr().MethodByName(name)
+	}
+
+	if method.IsValid() {
+		err := check_signature(name, method.Interface())
+		if err != nil {
+			return reflect.Value{}, NewErrField(path, name, err)
+		} else if method.Type().NumIn() > 0 {
+			return reflect.Value{}, NewErrField(path, name, fmt.Errorf("method of %s takes arguments", elem.Type().String()))
+		}
+
+		return call_func(name, method, nil)
+	}
+
+	switch elem.Kind() {
+	case reflect.Struct:
+		sf, ok := struct_field(elem.Type(), name, s.tag)
+		if !ok {
+			return reflect.Value{}, NewErrField(path, name, fmt.Errorf("no such field in %s", elem.Type().String()))
+		} else if !sf.IsExported() {
+			return reflect.Value{}, NewErrField(path, name, fmt.Errorf("field of %s is not exported", elem.Type().String()))
+		}
+
+		value, err := elem.FieldByIndexErr(sf.Index)
+		if err != nil {
+			return reflect.Value{}, NewErrField(path, name, err)
+		}
+
+		return unwrap(value), nil
+	case reflect.Map:
+		key_type := elem.Type().Key()
+
+		if key_type.Kind() != reflect.String {
+			return reflect.Value{}, NewErrField(path, name, fmt.Errorf("keys of %s are not strings", elem.Type().String()))
+		}
+
+		value := elem.MapIndex(reflect.ValueOf(name).Convert(key_type))
+		if !value.IsValid() {
+			return reflect.Value{}, NewErrField(path, name, fmt.Errorf("no such key in %s", elem.Type().String()))
+		}
+
+		return unwrap(value), nil
+	default:
+		return reflect.Value{}, NewErrField(path, name, fmt.Errorf("%s has no fields", elem.Type().String()))
+	}
+}
+
+// struct_field is a helper function that finds a field of a struct; promoted fields
+// included. Fields named by their tag take precedence over fields with the given name.
+//
+// Parameters:
+//   - typ: The type of the struct.
+//   - name: The name of the field.
+//   - tag: The key of the struct tags that name the fields. Empty to only use the names.
+//
+// Returns:
+//   - reflect.StructField: The field.
+//   - bool: True if the field exists, false otherwise.
+func struct_field(typ reflect.Type, name, tag string) (reflect.StructField, bool) {
+	if tag != "" {
+		var found *reflect.StructField
+
+		for _, sf := range reflect.VisibleFields(typ) {
+			tag_name, _, _ := strings.Cut(sf.Tag.Get(tag), ",")
+			if tag_name != name || !sf.IsExported() {
+				continue
+			}
+
+			if found == nil || len(sf.Index) < len(found.Index) {
+				found = &sf
+			}
+		}
+
+		if found != nil {
+			return *found, true
+		}
+	}
+
+	return typ.FieldByName(name)
+}
+
+// write_string is a helper function that writes a string to the writer.
+//
+// Parameters:
+//   - w: The writer.
+//   - str: The string to write.
+//
+// Returns:
+//   - error: An error if the string could not be written.
+func write_string(w io.Writer, str string) error {
+	bytes := []byte(str)
+
+	n, err := w.Write(bytes)
+	if err != nil {
+		return err
+	} else if n != len(bytes) {
+		return errors.New("failed to write all bytes")
+	}
+
+	return nil
+}
+
+// action is a helper function that returns an action written with the delimiters of the
+// template.
+//
+// Parameters:
+//   - text: The text of the action. (e.g., "end")
+//
+// Returns:
+//   - string: The action. (e.g., "{{ end }}")
+func (t *Template) action(text string) string {
+	return t.open + " " + text + " " + t.close
+}
+
+// branch_action is a helper function that returns the action that opens a branch of a
+// block.
+//
+// Parameters:
+//   - node: The if, range, define or block node.
+//   - idx: The index of the branch.
+//
+// Returns:
+//   - string: The action. (e.g., "{{ else if .A }}")
+func (t *Template) branch_action(node *Node, idx int) string {
+	branch := node.Children[idx]
+
+	var builder strings.Builder
+
+	switch {
+	case idx > 0 && branch.Data == "":
+		builder.WriteString("else")
+	case idx > 0:
+		builder.WriteString("else if ")
+		builder.WriteString(branch.Data)
+	case node.Kind == BlockNode:
+		builder.WriteString("block ")
+		builder.WriteString(strconv.Quote(node.Data))
+		builder.WriteString(" ")
+		builder.WriteString(branch.Data)
+	case node.Kind == DefineNode:
+		builder.WriteString("define ")
+		builder.WriteString(strconv.Quote(node.Data))
+	case node.Kind == RangeNode:
+		builder.WriteString("range ")
+
+		if len(node.Vars) > 0 {
+			builder.WriteString(strings.Join(node.Vars, ", "))
+			builder.WriteString(" := ")
+		}
+
+		builder.WriteString(branch.Data)
+	default:
+		builder.WriteString("if ")
+		builder.WriteString(branch.Data)
+	}
+
+	return t.action(builder.String())
+}
+
+// write_nodes is a helper function that writes the given nodes. Nodes that were not
+// applied are written back as actions.
+//
+// Parameters:
+//   - w: The writer.
+//   - nodes: The nodes to write.
+//
+// Returns:
+//   - error: An error if the nodes could not be written.
+func (t *Template) write_nodes(w io.Writer, nodes []*Node) error {
+	for _, node := range nodes {
+		var err error
+
+		switch node.Kind {
+		case VariableNode:
+			err = write_string(w, t.action(node.Data))
+		case AssignNode:
+			err = write_string(w, t.action(node.Vars[0]+" := "+node.Data))
+		case TextNode:
+			err = write_string(w, node.Data)
+		case CommentNode:
+			err = write_string(w, t.open+node.Data+t.close)
+		case TemplateNode:
+			text := "template " + strconv.Quote(node.Data)
+
+			if node.Pipe != nil {
+				text += " " + node.Pipe.Data
+			}
+
+			err = write_string(w, t.action(text))
+		case IfNode, RangeNode, DefineNode, BlockNode:
+			for i, branch := range node.Children {
+				err = write_string(w, t.branch_action(node, i))
+				if err != nil {
+					return err
+				}
+
+				err = t.write_nodes(w, branch.Children)
+				if err != nil {
+					return err
+				}
+			}
+
+			err = write_string(w, t.action("end"))
+		default:
+			err = fmt.Errorf("invalid node: %s", node.Kind.String())
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Write writes the template; nodes that were not applied are written back as actions.
+//
+// Parameters:
+//   - w: The writer.
+//
+// Returns:
+//   - error: An error if the template could not be written.
+func (t *Template) Write(w io.Writer) error {
+	if w == nil {
+		return uc.NewErrNilParameter("w")
+	}
+
+	return t.write_nodes(w, t.root.Children)
+}
+
+// Execute applies the data to the template and writes the result. The template is not
+// modified; thus, it can be executed any number of times and from several goroutines at
+// once.
+//
+// Parameters:
+//   - w: The writer.
+//   - data: The data to apply.
+//   - opts: The options of the execution. (e.g., OnMissing)
+//
+// Returns:
+//   - error: An error if the data could not be applied or the result could not be written.
+//     Nothing is written if the data could not be applied.
+func (t *Template) Execute(w io.Writer, data any, opts ...ExecOption) error {
+	if w == nil {
+		return uc.NewErrNilParameter("w")
+	}
+
+	nodes, err := t.evaluate(data, opts)
+	if err != nil {
+		return fmt.Errorf("failed to apply template: %w", err)
+	}
+
+	err = t.write_nodes(w, nodes)
+	if err != nil {
+		return fmt.Errorf("failed to write template: %w", err)
+	}
+
+	return nil
+}
+
+// with_root is a helper function that creates a template with the same options and
+// sub-templates as this one, but with the given AST.
+//
+// Parameters:
+//   - root: The root node of the AST.
+//
+// Returns:
+//   - *Template: The template. Never returns nil.
+func (t *Template) with_root(root *Node) *Template {
+	return &Template{
+		root:     root,
+		funcs:    t.funcs,
+		sep:      t.sep,
+		nil_text: t.nil_text,
+		tag:      t.tag,
+		open:     t.open,
+		close:    t.close,
+		defs:     t.defs,
+	}
+}
@@ -0,0 +1,1028 @@
+package pkg
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// execute is a helper function that executes the template on the given data.
+func execute(t *testing.T, str string, data any) (string, error) {
+	t.Helper()
+
+	return execute_with(t, str, data)
+}
+
+// execute_with is like execute but it creates the template with the given options.
+func execute_with(t *testing.T, str string, data any, opts ...Option) (string, error) {
+	t.Helper()
+
+	tmpl, err := NewTemplate(str, opts...)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	var builder strings.Builder
+
+	err = tmpl.Execute(&builder, data)
+
+	return builder.String(), err
+}
+
+func TestFieldPath(t *testing.T) {
+	type DataType struct {
+		Name string
+		Sig  string
+	}
+
+	type GenData struct {
+		Type   *DataType
+		Any    any
+		Extras map[string]string
+	}
+
+	data := &GenData{
+		Type:   &DataType{Name: "Stack", Sig: "Stack[T]"},
+		Any:    DataType{Name: "Queue"},
+		Extras: map[string]string{"pkg": "stack"},
+	}
+
+	res, err := execute(t, "package {{ .Extras.pkg }}\n\ntype {{ .Type.Sig }} {{ .Any.Name }}", data)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	expected := "package stack\n\ntype Stack[T] Queue"
+
+	if res != expected {
+		t.Errorf("expected %q, got %q", expected, res)
+	}
+}
+
+func TestFieldPathMissing(t *testing.T) {
+	type DataType struct {
+		Name string
+	}
+
+	type GenData struct {
+		Type *DataType
+	}
+
+	tests := []struct {
+		data  any
+		field string
+	}{
+		{GenData{Type: &DataType{}}, "Sig"},
+		{GenData{}, "Sig"},
+	}
+
+	for _, test := range tests {
+		_, err := execute(t, "{{ .Type.Sig }}", test.data)
+		if err == nil {
+			t.Fatalf("expected error, got nil")
+		}
+
+		var field_err *ErrField
+
+		if !errors.As(err, &field_err) {
+			t.Fatalf("expected *ErrField, got %T", err)
+		}
+
+		if field_err.Path != ".Type.Sig" || field_err.Field != test.field {
+			t.Errorf("expected field %q of \".Type.Sig\", got %q of %q", test.field, field_err.Field, field_err.Path)
+		}
+
+		if !strings.Contains(err.Error(), "\".Type.Sig\"") {
+			t.Errorf("expected the error to name the full path, got %q", err.Error())
+		}
+	}
+}
+
+func TestIf(t *testing.T) {
+	type GenData struct {
+		Capacity int
+		Name     string
+		Kind     string
+		Tags     []string
+	}
+
+	str := "{{ if .Capacity }}cap{{ else if .Tags }}tags{{ else }}none{{ end }}|" +
+		"{{ if .Name }}{{ if .Kind }}{{ .Kind }} {{ end }}{{ .Name }}{{ end }}"
+
+	tests := []struct {
+		data     GenData
+		expected string
+	}{
+		{GenData{Capacity: 3, Name: "Stack"}, "cap|Stack"},
+		{GenData{Tags: []string{"a"}, Name: "Stack", Kind: "linked"}, "tags|linked Stack"},
+		{GenData{}, "none|"},
+	}
+
+	for _, test := range tests {
+		res, err := execute(t, str, test.data)
+		if err != nil {
+			t.Fatalf("expected no error, got %s", err.Error())
+		}
+
+		if res != test.expected {
+			t.Errorf("expected %q, got %q", test.expected, res)
+		}
+	}
+}
+
+func TestIfInterfaces(t *testing.T) {
+	str := "{{ if .F }}F{{ end }}{{ if .Z }}Z{{ end }}{{ if .E }}E{{ end }}{{ if .N }}N{{ end }}" +
+		"{{ if .T }}T{{ end }}{{ if .Any.F }}AF{{ end }}{{ if .Any.T }}AT{{ end }}"
+
+	type Fields struct {
+		F any
+		T any
+	}
+
+	// The values held by the interfaces are checked; not the interfaces.
+	data := map[string]any{
+		"F": false, "Z": 0, "E": "", "N": nil, "T": true,
+		"Any": Fields{F: false, T: 1},
+	}
+
+	res, err := execute(t, str, data)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	if res != "TAT" {
+		t.Errorf("expected %q, got %q", "TAT", res)
+	}
+}
+
+func TestIfErrors(t *testing.T) {
+	tests := []struct {
+		str      string
+		expected string
+	}{
+		{"a {{ if .A }}b", "1:3: unclosed if block; expected {{ end }}"},
+		{"a\n{{ end }}", "2:1: unexpected end outside of a block"},
+		{"{{ if .A }}{{ else }}{{ else }}{{ end }}", "1:22: unexpected else after the else branch"},
+		{"{{ else }}", "1:1: unexpected else outside of a block"},
+	}
+
+	for _, test := range tests {
+		_, err := NewTemplate(test.str)
+		if err == nil {
+			t.Fatalf("expected error for %q, got nil", test.str)
+		}
+
+		if !strings.Contains(err.Error(), test.expected) {
+			t.Errorf("expected %q to contain %q", err.Error(), test.expected)
+		}
+	}
+}
+
+func TestLexErrors(t *testing.T) {
+	_, err := NewTemplate("a {{ .A ) }} b {{ .B ) }}")
+	if err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+
+	// Every broken action is reported.
+	for _, want := range []string{"1:9: unexpected character ')'", "1:22: unexpected character ')'"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q to contain %q", err.Error(), want)
+		}
+	}
+}
+
+func TestRange(t *testing.T) {
+	type Symbol struct {
+		Name  string
+		Const string
+	}
+
+	type GenData struct {
+		Type    string
+		Symbols []*Symbol
+		Descs   map[string]string
+		Empty   []string
+	}
+
+	data := GenData{
+		Type: "TokenType",
+		Symbols: []*Symbol{
+			{Name: "EOF", Const: "TkEOF"},
+			{Name: "dot", Const: "TkDot"},
+		},
+		Descs: map[string]string{"TkDot": "dot", "TkEOF": "End of File"},
+	}
+
+	tests := []struct {
+		str      string
+		expected string
+	}{
+		{"{{ range .Symbols }}case {{ .Name }}: return {{ .Const }}; {{ end }}", "case EOF: return TkEOF; case dot: return TkDot; "},
+		{"{{ range $i, $s := .Symbols }}{{ $s.Const }} {{ $.Type }} = {{ $i }}; {{ end }}", "TkEOF TokenType = 0; TkDot TokenType = 1; "},
+		{"{{ range $k, $v := .Descs }}{{ $k }}:{{ $v }} {{ end }}", "TkDot:dot TkEOF:End of File "},
+		{"{{ range $v := .Descs }}{{ . }}/{{ $v }} {{ end }}", "dot/dot End of File/End of File "},
+		{"{{ range .Empty }}x{{ else }}empty{{ end }}", "empty"},
+	}
+
+	for _, test := range tests {
+		res, err := execute(t, test.str, data)
+		if err != nil {
+			t.Fatalf("expected no error, got %s", err.Error())
+		}
+
+		if res != test.expected {
+			t.Errorf("expected %q, got %q", test.expected, res)
+		}
+	}
+}
+
+func TestRangeErrors(t *testing.T) {
+	tests := []string{
+		"{{ range .A }}",
+		"{{ range .A }}{{ else if .B }}{{ end }}",
+		"{{ $v }}",
+		"{{ range $v := .A }}{{ end }}{{ $v }}",
+	}
+
+	expecteds := []string{
+		"1:1: unclosed range block; expected {{ end }}",
+		"1:15: unexpected else if in a range block",
+		"1:4: undefined variable $v",
+		"1:33: undefined variable $v",
+	}
+
+	for i, str := range tests {
+		_, err := NewTemplate(str)
+		if err == nil {
+			t.Fatalf("expected error for %q, got nil", str)
+		}
+
+		if !strings.Contains(err.Error(), expecteds[i]) {
+			t.Errorf("expected %q to contain %q", err.Error(), expecteds[i])
+		}
+	}
+}
+
+func TestPipelines(t *testing.T) {
+	funcs := FuncMap{
+		"upper": strings.ToUpper,
+		"join": func(sep string, elems ...string) string {
+			return strings.Join(elems, sep)
+		},
+		"add": func(a, b int64) int64 {
+			return a + b
+		},
+		"not": func(b bool) bool {
+			return !b
+		},
+		"half": func(n uint8) uint8 {
+			return n / 2
+		},
+	}
+
+	type GenData struct {
+		Name string
+		Size int
+	}
+
+	data := GenData{Name: "stack", Size: 2}
+
+	tests := []struct {
+		str      string
+		expected string
+	}{
+		{`{{ .Name | upper }}`, "STACK"},
+		{`{{ join "_" .Name "test" | upper }}`, "STACK_TEST"},
+		{`{{ add .Size 40 }} {{ 1.5 }} {{ "a\tb" }} {{ true }}`, "42 1.5 a\tb true"},
+		{`{{ $x := upper .Name }}{{ if not false }}{{ $x }}{{ $y := 1 }}{{ end }}{{ $x }}`, "STACKSTACK"},
+		{`{{ if .Size }}{{ $n := .Name }}{{ $n }}{{ else }}{{ .Size }}{{ end }}`, "stack"},
+		{`{{ half 4.0 }} {{ half .Size }}`, "2 1"},
+	}
+
+	for _, test := range tests {
+		res, err := execute_with(t, test.str, data, WithFuncs(funcs))
+		if err != nil {
+			t.Fatalf("expected no error for %q, got %s", test.str, err.Error())
+		}
+
+		if res != test.expected {
+			t.Errorf("expected %q, got %q", test.expected, res)
+		}
+	}
+}
+
+func TestPipelineErrors(t *testing.T) {
+	funcs := FuncMap{
+		"upper": strings.ToUpper,
+		"fail": func() (string, error) {
+			return "", errors.New("failed")
+		},
+		"half": func(n uint8) uint8 {
+			return n / 2
+		},
+	}
+
+	parse_tests := []struct {
+		str      string
+		expected string
+	}{
+		{`{{ lower .Name }}`, `1:4: function "lower" is not defined`},
+		{`{{ upper .Name "x" }}`, `1:4: wrong number of arguments for upper: expected 1, got 2`},
+		{`{{ .Name | upper "x" }}`, `1:12: wrong number of arguments for upper: expected 1, got 2`},
+		{`{{ .Name "x" }}`, `1:10: cannot give arguments to a value that is not a function`},
+		{`{{ upper .Name | .Name }}`, `1:18: cannot pipe into a value that is not a function`},
+		{`{{ $x }}{{ $x := 1 }}`, `1:4: undefined variable $x`},
+		{`{{ if true }}{{ $x := 1 }}{{ end }}{{ $x }}`, `1:39: undefined variable $x`},
+	}
+
+	for _, test := range parse_tests {
+		_, err := NewTemplate(test.str, WithFuncs(funcs))
+		if err == nil {
+			t.Fatalf("expected error for %q, got nil", test.str)
+		}
+
+		if !strings.Contains(err.Error(), test.expected) {
+			t.Errorf("expected %q to contain %q", err.Error(), test.expected)
+		}
+	}
+
+	apply_tests := []struct {
+		str      string
+		expected string
+	}{
+		{`{{ upper .Size }}`, "calling upper: wrong type for argument 1: expected string, got int instead"},
+		{`{{ fail }}`, "calling fail: failed"},
+		{`{{ half -1 }}`, "calling half: wrong type for argument 1: -1 cannot be represented by uint8"},
+		{`{{ half 2.5 }}`, "calling half: wrong type for argument 1: 2.5 cannot be represented by uint8"},
+		{`{{ half 300 }}`, "calling half: wrong type for argument 1: 300 cannot be represented by uint8"},
+	}
+
+	for _, test := range apply_tests {
+		_, err := execute_with(t, test.str, struct{ Size int }{1}, WithFuncs(funcs))
+		if err == nil {
+			t.Fatalf("expected error for %q, got nil", test.str)
+		}
+
+		var call_err *ErrCall
+
+		if !errors.As(err, &call_err) {
+			t.Errorf("expected *ErrCall, got %T", err)
+		} else if call_err.Error() != test.expected {
+			t.Errorf("expected %q, got %q", test.expected, call_err.Error())
+		}
+	}
+
+	_, err := NewTemplate("x", WithFuncs(FuncMap{"bad": func() {}}))
+	if err == nil {
+		t.Errorf("expected error for a function without results, got nil")
+	}
+
+	for _, name := range []string{"define", "template", "block"} {
+		_, err = NewTemplate("x", WithFuncs(FuncMap{name: strings.ToUpper}))
+		if err == nil || !strings.Contains(err.Error(), fmt.Sprintf("%q is not a valid function name", name)) {
+			t.Errorf("expected an error for the keyword %q, got %v", name, err)
+		}
+	}
+
+	// The errors are in the order of the names of the functions.
+	_, err = NewTemplate("x", WithFuncs(FuncMap{"c": 3, "a": 1, "b": 2}))
+	if err == nil {
+		t.Fatalf("expected error for values that are not functions, got nil")
+	}
+
+	expected := "invalid functions: a is not a function\nb is not a function\nc is not a function"
+
+	if err.Error() != expected {
+		t.Errorf("expected %q, got %q", expected, err.Error())
+	}
+}
+
+func TestExecuteReusable(t *testing.T) {
+	type GenData struct {
+		Name  string
+		Elems []string
+	}
+
+	tmpl, err := NewTemplate(`{{ .Name }}:{{ range $i, $e := .Elems }} {{ $i }}={{ $e }}{{ end }}`)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	var wg sync.WaitGroup
+
+	results := make([]string, 20)
+	errs := make([]error, len(results))
+
+	for i := range results {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			data := GenData{Name: fmt.Sprintf("t%d", i), Elems: []string{"a", fmt.Sprint(i)}}
+
+			var builder strings.Builder
+
+			errs[i] = tmpl.Execute(&builder, data)
+			results[i] = builder.String()
+		}(i)
+	}
+
+	wg.Wait()
+
+	for i, res := range results {
+		if errs[i] != nil {
+			t.Fatalf("expected no error, got %s", errs[i].Error())
+		}
+
+		expected := fmt.Sprintf("t%d: 0=a 1=%d", i, i)
+
+		if res != expected {
+			t.Errorf("expected %q, got %q", expected, res)
+		}
+	}
+
+	var builder strings.Builder
+
+	err = tmpl.Write(&builder)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	expected := `{{ .Name }}:{{ range $i, $e := .Elems }} {{ $i }}={{ $e }}{{ end }}`
+
+	if builder.String() != expected {
+		t.Errorf("expected the template to be unchanged, got %q", builder.String())
+	}
+}
+
+// kind is a type that implements fmt.Stringer.
+type kind int
+
+// String implements the fmt.Stringer interface.
+func (k kind) String() string {
+	return [...]string{"struct", "union"}[k]
+}
+
+// decl is a type that implements fmt.GoStringer.
+type decl struct {
+	name string
+}
+
+// GoString implements the fmt.GoStringer interface.
+func (d *decl) GoString() string {
+	return "type " + d.name
+}
+
+func TestFormatValues(t *testing.T) {
+	type GenData struct {
+		Int    int8
+		Uint   uint
+		Float  float32
+		Bool   bool
+		Kind   kind
+		Decl   *decl
+		Nil    *decl
+		Any    any
+		Names  []string
+		Kinds  [2]kind
+		Nested [][]int
+	}
+
+	data := GenData{
+		Int:    -3,
+		Uint:   7,
+		Float:  0.1,
+		Bool:   true,
+		Kind:   1,
+		Decl:   &decl{name: "Stack"},
+		Names:  []string{"a", "b"},
+		Kinds:  [2]kind{0, 1},
+		Nested: [][]int{{1, 2}, {3}},
+	}
+
+	str := `{{ .Int }} {{ .Uint }} {{ .Float }} {{ .Bool }} {{ .Kind }} {{ .Decl }} {{ .Nil }} {{ .Any }} ` +
+		`{{ .Names }} {{ .Kinds }} {{ .Nested }}`
+
+	res, err := execute(t, str, data)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	expected := "-3 7 0.1 true union type Stack   a, b struct, union 1, 2, 3"
+
+	if res != expected {
+		t.Errorf("expected %q, got %q", expected, res)
+	}
+
+	res, err = execute_with(t, str, data, WithSeparator("|"), WithNilText("nil"))
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	expected = "-3 7 0.1 true union type Stack nil nil a|b struct|union 1|2|3"
+
+	if res != expected {
+		t.Errorf("expected %q, got %q", expected, res)
+	}
+
+	for _, value := range []any{struct{}{}, map[string]int{}, make(chan int)} {
+		_, err := execute(t, "{{ .Value }}", struct{ Value any }{value})
+
+		var format_err *ErrFormat
+
+		if !errors.As(err, &format_err) {
+			t.Errorf("expected *ErrFormat for %T, got %v", value, err)
+		}
+	}
+}
+
+// Base is a struct embedded in the data of TestDataSources.
+type Base struct {
+	Pkg string `json:"package"`
+}
+
+// Struct is the data of TestDataSources.
+type Struct struct {
+	Base
+
+	Name string `json:"name,omitempty"`
+	size int
+}
+
+// Upper returns the name in uppercase.
+func (s Struct) Upper() string {
+	return strings.ToUpper(s.Name)
+}
+
+// Size returns the size; it has a pointer receiver.
+func (s *Struct) Size() int {
+	return s.size
+}
+
+// Fail always fails.
+func (s Struct) Fail() (string, error) {
+	return "", errors.New("failed")
+}
+
+// Prefix takes an argument and so cannot be used as a field.
+func (s Struct) Prefix(p string) string {
+	return p + s.Name
+}
+
+func TestDataSources(t *testing.T) {
+	data := &Struct{Base: Base{Pkg: "stack"}, Name: "Stack", size: 3}
+
+	res, err := execute(t, `{{ .Pkg }} {{ .Base.Pkg }} {{ .Name }} {{ .Upper }} {{ .Size }}`, data)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	expected := "stack stack Stack STACK 3"
+
+	if res != expected {
+		t.Errorf("expected %q, got %q", expected, res)
+	}
+
+	res, err = execute_with(t, `{{ .package }} {{ .name }} {{ .Name }}`, data, WithTagName("json"))
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	expected = "stack Stack Stack"
+
+	if res != expected {
+		t.Errorf("expected %q, got %q", expected, res)
+	}
+
+	config := map[string]any{
+		"type": map[string]any{"name": "Queue", "elems": []any{"a", 1.5}},
+	}
+
+	res, err = execute(t, `{{ .type.name }}{{ range .type.elems }} {{ . }}{{ end }}`, &config)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	expected = "Queue a 1.5"
+
+	if res != expected {
+		t.Errorf("expected %q, got %q", expected, res)
+	}
+
+	tests := []struct {
+		str      string
+		expected string
+	}{
+		{`{{ .Size }}`, `cannot resolve "Size" of ".Size": no such field in pkg.Struct`},
+		{`{{ .Prefix }}`, `cannot resolve "Prefix" of ".Prefix": method of pkg.Struct takes arguments`},
+		{`{{ .Fail }}`, `calling Fail: failed`},
+	}
+
+	for _, test := range tests {
+		_, err := execute(t, test.str, *data)
+		if err == nil {
+			t.Fatalf("expected error for %q, got nil", test.str)
+		}
+
+		if !strings.Contains(err.Error(), test.expected) {
+			t.Errorf("expected %q to contain %q", err.Error(), test.expected)
+		}
+	}
+
+	_, err = execute(t, `{{ .Name }}`, (*Struct)(nil))
+	if err == nil {
+		t.Errorf("expected error for nil data, got nil")
+	}
+
+	// A scope without data cannot resolve fields.
+	_, err = new_scope(reflect.Value{}, "").resolve(".Name")
+
+	var field_err *ErrField
+
+	if !errors.As(err, &field_err) || !strings.Contains(err.Error(), "nil data") {
+		t.Errorf("expected *ErrField about nil data, got %v", err)
+	}
+}
+
+func TestMissingPolicies(t *testing.T) {
+	type GenData struct {
+		Name string
+		Nil  *Struct
+	}
+
+	str := "{{ .Name }} {{ .Typo }}{{ if .Cond }}x{{ end }}{{ range .Elems }}y{{ end }}\n{{ .Typo | default \"T\" }} {{ default .Name .Nil.Name }}"
+
+	tmpl, err := NewTemplate(str)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	data := GenData{Name: "stack"}
+
+	var builder strings.Builder
+
+	err = tmpl.Execute(&builder, data)
+
+	var field_err *ErrField
+
+	if !errors.As(err, &field_err) {
+		t.Fatalf("expected *ErrField, got %v", err)
+	} else if !strings.Contains(err.Error(), `1:16: cannot resolve "Typo" of ".Typo"`) {
+		t.Errorf("expected the error to give the path and its position, got %q", err.Error())
+	}
+
+	tests := []struct {
+		policy   MissingPolicy
+		expected string
+	}{
+		{MissingZero, "stack \nT stack"},
+		{MissingKeep, "stack {{ .Typo }}{{ if .Cond }}x{{ end }}{{ range .Elems }}y{{ end }}\n{{ .Typo | default \"T\" }} {{ default .Name .Nil.Name }}"},
+	}
+
+	for _, test := range tests {
+		builder.Reset()
+
+		err := tmpl.Execute(&builder, data, OnMissing(test.policy))
+		if err != nil {
+			t.Fatalf("expected no error, got %s", err.Error())
+		}
+
+		if builder.String() != test.expected {
+			t.Errorf("expected %q, got %q", test.expected, builder.String())
+		}
+	}
+
+	// A missing field is not a nil value.
+	tmpl, err = NewTemplate("{{ .Typo }}|{{ .Nil }}", WithNilText("nil"))
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	builder.Reset()
+
+	err = tmpl.Execute(&builder, data, OnMissing(MissingZero))
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	if builder.String() != "|nil" {
+		t.Errorf("expected %q, got %q", "|nil", builder.String())
+	}
+
+	tmpl, err = NewTemplate("{{ .Typo | default \"T\" }} {{ default .Name .Nil.Name }} {{ default \"x\" .Name }}")
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	builder.Reset()
+
+	err = tmpl.Execute(&builder, data, OnMissing(MissingDefault))
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	expected := "T stack stack"
+
+	if builder.String() != expected {
+		t.Errorf("expected %q, got %q", expected, builder.String())
+	}
+
+	_, err = execute_with(t, "{{ .Typo }}", data)
+	if err == nil {
+		t.Errorf("expected error for a missing field without a default, got nil")
+	}
+
+	// The fields of a missing value are missing too.
+	tmpl, err = NewTemplate("{{ $x := .Typo }}[{{ $x.A }}]")
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	chained := []struct {
+		policy   MissingPolicy
+		expected string
+	}{
+		{MissingError, ""},
+		{MissingZero, "[]"},
+		{MissingKeep, "{{ $x := .Typo }}[{{ $x.A }}]"},
+		{MissingDefault, ""},
+	}
+
+	for _, test := range chained {
+		builder.Reset()
+
+		err := tmpl.Execute(&builder, data, OnMissing(test.policy))
+
+		if test.expected == "" {
+			if !errors.As(err, &field_err) {
+				t.Errorf("expected *ErrField for policy %d, got %v", test.policy, err)
+			}
+		} else if err != nil {
+			t.Errorf("expected no error for policy %d, got %s", test.policy, err.Error())
+		} else if builder.String() != test.expected {
+			t.Errorf("expected %q for policy %d, got %q", test.expected, test.policy, builder.String())
+		}
+	}
+}
+
+func TestTrimMarkers(t *testing.T) {
+	type GenData struct {
+		Name  string
+		Elems []string
+	}
+
+	str := "type {{ .Name }} struct {\n\t{{- range .Elems }}\n\t{{ . }} int\n\t{{- end }}\n}\n\n{{- if .Name }}  \n  ok {{- end -}}  \n\n!"
+
+	res, err := execute(t, str, GenData{Name: "Stack", Elems: []string{"a", "b"}})
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	expected := "type Stack struct {\n\ta int\n\tb int\n}  \n  ok!"
+
+	if res != expected {
+		t.Errorf("expected %q, got %q", expected, res)
+	}
+}
+
+func TestComments(t *testing.T) {
+	str := "{{/* Generated by the stack generator.\n   Do not edit. */ -}}\npackage {{ .Name }}{{/* no output */}}\n"
+
+	tmpl, err := NewTemplate(str)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	if !strings.Contains(PrintAST(tmpl.root), "Node[Comment (/* no output */)]") {
+		t.Errorf("expected the AST to keep the comments, got:\n%s", PrintAST(tmpl.root))
+	}
+
+	var builder strings.Builder
+
+	err = tmpl.Execute(&builder, struct{ Name string }{"stack"})
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	expected := "package stack\n"
+
+	if builder.String() != expected {
+		t.Errorf("expected %q, got %q", expected, builder.String())
+	}
+
+	next, _, err := tmpl.Partial(struct{}{})
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	builder.Reset()
+
+	err = next.Write(&builder)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	expected = "{{/* Generated by the stack generator.\n   Do not edit. */}}package {{ .Name }}{{/* no output */}}\n"
+
+	if builder.String() != expected {
+		t.Errorf("expected %q, got %q", expected, builder.String())
+	}
+}
+
+func TestDelims(t *testing.T) {
+	str := "[[- if .Name ]]type [[ .Name ]] {{ x }}[[ else ]]none[[ end -]]\n[[ .Kind ]][[/* c */]]"
+
+	tmpl, err := NewTemplate(str, WithDelims("[[", "]]"))
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	var builder strings.Builder
+
+	err = tmpl.Execute(&builder, map[string]any{"Name": "stack", "Kind": "struct"})
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	expected := "type stack {{ x }}struct"
+
+	if builder.String() != expected {
+		t.Errorf("expected %q, got %q", expected, builder.String())
+	}
+
+	next, _, err := tmpl.Partial(map[string]any{"Kind": "struct"})
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	builder.Reset()
+
+	err = next.Write(&builder)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	expected = "[[ if .Name ]]type [[ .Name ]] {{ x }}[[ else ]]none[[ end ]]struct[[/* c */]]"
+
+	if builder.String() != expected {
+		t.Errorf("expected %q, got %q", expected, builder.String())
+	}
+
+	_, err = NewTemplate("a [[ if .A ]]b", WithDelims("[[", "]]"))
+	if err == nil || !strings.Contains(err.Error(), "expected [[ end ]]") {
+		t.Errorf("expected an error about the end action, got %v", err)
+	}
+}
+
+func TestSubTemplates(t *testing.T) {
+	str := "{{ define \"field\" }}{{ .Name }} {{ .Type }}{{ end }}" +
+		"type {{ .Name }} struct { {{- range .Fields }} {{ template \"field\" . }};{{ end }} }" +
+		"{{ block \"new\" .Name }} func New{{ . }}() *{{ . }}{{ end }}"
+
+	tmpl, err := NewTemplate(str)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	type Field struct {
+		Name string
+		Type string
+	}
+
+	data := struct {
+		Name   string
+		Fields []Field
+	}{"Stack", []Field{{"top", "*node"}, {"size", "int"}}}
+
+	var builder strings.Builder
+
+	err = tmpl.Execute(&builder, data)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	expected := "type Stack struct { top *node; size int; } func NewStack() *Stack"
+
+	if builder.String() != expected {
+		t.Errorf("expected %q, got %q", expected, builder.String())
+	}
+
+	next, unresolved, err := tmpl.Partial(struct{ Fields []Field }{})
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	builder.Reset()
+
+	err = next.Write(&builder)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	expected = "{{ define \"field\" }}{{ .Name }} {{ .Type }}{{ end }}type {{ .Name }} struct { }" +
+		"{{ block \"new\" .Name }} func New{{ . }}() *{{ . }}{{ end }}"
+
+	if builder.String() != expected {
+		t.Errorf("expected %q, got %q", expected, builder.String())
+	}
+
+	if !slices.Equal(unresolved, []string{".Name"}) {
+		t.Errorf("expected [.Name] to be unresolved, got %v", unresolved)
+	}
+
+	builder.Reset()
+
+	err = next.Execute(&builder, data)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	expected = "type Stack struct { } func NewStack() *Stack"
+
+	if builder.String() != expected {
+		t.Errorf("expected %q, got %q", expected, builder.String())
+	}
+
+	tests := []struct {
+		str      string
+		expected string
+	}{
+		{"{{ if .A }}{{ define \"x\" }}{{ end }}{{ end }}", "1:12: unexpected define inside a block"},
+		{"{{ $v := 1 }}{{ define \"x\" }}{{ $v }}{{ end }}", "1:33: undefined variable $v"},
+		{"{{ block \"x\" . }}{{ else }}{{ end }}", "1:18: unexpected else in the body of a block"},
+		{"{{ block \"x\" . }}", "1:1: unclosed block; expected {{ end }}"},
+		{"{{ define \"x\" }}", "1:1: unclosed define block; expected {{ end }}"},
+		{"{{ template \"x\" }}", "1:1: template \"x\" is not defined"},
+	}
+
+	for _, test := range tests {
+		_, err := NewTemplate(test.str)
+		if err == nil {
+			t.Fatalf("expected error for %q, got nil", test.str)
+		}
+
+		if !strings.Contains(err.Error(), test.expected) {
+			t.Errorf("expected %q to contain %q", err.Error(), test.expected)
+		}
+	}
+}
+
+func TestSubTemplateWithoutDot(t *testing.T) {
+	str := "{{ define \"x\" }}[{{ .A }}]{{ end }}{{ template \"x\" }}"
+
+	tmpl, err := NewTemplate(str)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	data := map[string]any{"A": 1}
+
+	// The sub-template has no dot; whatever the data.
+	var builder strings.Builder
+
+	err = tmpl.Execute(&builder, data)
+
+	var field_err *ErrField
+
+	if !errors.As(err, &field_err) || !strings.Contains(err.Error(), `1:21: cannot resolve "A" of ".A": nil data`) {
+		t.Errorf("expected *ErrField about nil data, got %v", err)
+	}
+
+	builder.Reset()
+
+	err = tmpl.Execute(&builder, data, OnMissing(MissingZero))
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	if builder.String() != "[]" {
+		t.Errorf("expected %q, got %q", "[]", builder.String())
+	}
+
+	// The template action is kept since its sub-template needs the dot.
+	next, unresolved, err := tmpl.Partial(data)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	builder.Reset()
+
+	err = next.Write(&builder)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	if builder.String() != str {
+		t.Errorf("expected %q, got %q", str, builder.String())
+	}
+
+	if !slices.Equal(unresolved, []string{".A"}) {
+		t.Errorf("expected [.A] to be unresolved, got %v", unresolved)
+	}
+}
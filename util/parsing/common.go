@@ -1,6 +0,0 @@
-package parsing
-
-const (
-	// DebugMode is the debug mode. Default is true.
-	DebugMode bool = true
-)
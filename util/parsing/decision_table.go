@@ -0,0 +1,752 @@
+package parsing
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	uc "github.com/PlayerR9/lib_units/common"
+)
+
+// item_set is a state of the LR(1) automaton.
+type item_set[T TokenTyper] struct {
+	// items are the items of the state.
+	items []*Item[T]
+
+	// gotos are the transitions of the state. Both terminals and non-terminals are
+	// used as keys.
+	gotos map[T]int
+
+	// actions are the actions of the state for each lookahead.
+	actions map[T]Actioner[T]
+
+	// end is the action of the state when there is no lookahead. Nil if there is none.
+	end Actioner[T]
+}
+
+// find is a helper function that finds an item with the same core as the given item.
+//
+// Parameters:
+//   - item: The item to search.
+//
+// Returns:
+//   - *Item: The item. Nil if no item has the same core.
+func (is *item_set[T]) find(item *Item[T]) *Item[T] {
+	for _, other := range is.items {
+		if other.is_same_core(item) {
+			return other
+		}
+	}
+
+	return nil
+}
+
+// equals is a helper function that checks whether two states have the same items; lookaheads
+// included.
+//
+// Parameters:
+//   - other: The other state.
+//
+// Returns:
+//   - bool: True if the states are equal. False otherwise.
+func (is *item_set[T]) equals(other *item_set[T]) bool {
+	if len(is.items) != len(other.items) {
+		return false
+	}
+
+	for _, item := range is.items {
+		match := other.find(item)
+		if match == nil || !slices.Equal(item.lookaheads, match.lookaheads) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// DecisionTable is a decision table.
+type DecisionTable[T TokenTyper] struct {
+	// symbols is the list of symbols.
+	symbols []T
+
+	// rules is the list of rules.
+	rules []*Rule[T]
+
+	// helpers are the non-terminals introduced by repetitions; sorted.
+	helpers []T
+
+	// firsts are the terminals that can start each symbol.
+	firsts map[T][]T
+
+	// states are the states of the LR(1) automaton. The first state is the initial state.
+	states []*item_set[T]
+}
+
+// make_symbols is a helper function that returns the symbols in the grammar; ignoring duplicates.
+func (dt *DecisionTable[T]) make_symbols() {
+	uc.Assert(len(dt.rules) > 0, "rules must not be empty")
+
+	var symbols []T
+
+	for _, rule := range dt.rules {
+		pos, ok := slices.BinarySearch(symbols, rule.lhs)
+		if !ok {
+			symbols = slices.Insert(symbols, pos, rule.lhs)
+		}
+
+		for _, rhs := range rule.rhss {
+			pos, ok := slices.BinarySearch(symbols, rhs)
+			if !ok {
+				symbols = slices.Insert(symbols, pos, rhs)
+			}
+		}
+	}
+
+	dt.symbols = symbols
+}
+
+// check_rules is a helper function that checks that every non-terminal has at least one rule
+// and that no terminal is used as a left hand side.
+//
+// Returns:
+//   - error: An error if the rules are invalid.
+func (dt *DecisionTable[T]) check_rules() error {
+	for _, rule := range dt.rules {
+		if rule.lhs.IsTerminal() {
+			return fmt.Errorf("terminal %q cannot be the left hand side of a rule", rule.lhs.String())
+		}
+	}
+
+	for _, symbol := range dt.symbols {
+		if symbol.IsTerminal() {
+			continue
+		}
+
+		idx := slices.IndexFunc(dt.rules, func(rule *Rule[T]) bool {
+			return rule.lhs == symbol
+		})
+		if idx == -1 {
+			return fmt.Errorf("no rule has %q as left hand side", symbol.String())
+		}
+	}
+
+	return nil
+}
+
+// make_firsts is a helper function that computes, for each symbol, the terminals that can
+// start it.
+func (dt *DecisionTable[T]) make_firsts() {
+	uc.Assert(len(dt.symbols) > 0, "symbols must not be empty")
+
+	firsts := make(map[T][]T)
+
+	for _, symbol := range dt.symbols {
+		if symbol.IsTerminal() {
+			firsts[symbol] = []T{symbol}
+		}
+	}
+
+	for changed := true; changed; {
+		changed = false
+
+		for _, rule := range dt.rules {
+			start := rule.rhss[len(rule.rhss)-1]
+
+			prev := firsts[rule.lhs]
+
+			for _, first := range firsts[start] {
+				pos, ok := slices.BinarySearch(prev, first)
+				if !ok {
+					prev = slices.Insert(prev, pos, first)
+					changed = true
+				}
+			}
+
+			firsts[rule.lhs] = prev
+		}
+	}
+
+	dt.firsts = firsts
+}
+
+// closure is a helper function that computes the closure of the given items.
+//
+// Parameters:
+//   - items: The kernel items.
+//
+// Returns:
+//   - *item_set: The state. Never returns nil.
+func (dt *DecisionTable[T]) closure(items []*Item[T]) *item_set[T] {
+	is := &item_set[T]{
+		gotos:   make(map[T]int),
+		actions: make(map[T]Actioner[T]),
+	}
+
+	todo := make([]*Item[T], 0, len(items))
+
+	for _, item := range items {
+		prev := is.find(item)
+		if prev == nil {
+			is.items = append(is.items, item)
+			todo = append(todo, item)
+		} else if prev.add_lookaheads(item.lookaheads) {
+			todo = append(todo, prev)
+		}
+	}
+
+	for len(todo) > 0 {
+		item := todo[0]
+		todo = todo[1:]
+
+		next, ok := item.GetRhsRelative(-1)
+		if !ok || next.IsTerminal() {
+			continue
+		}
+
+		// Lookaheads of the new items are the first terminals of what follows 'next'; or
+		// the lookaheads of the item if nothing follows it.
+		var las []T
+
+		after, ok := item.GetRhsRelative(-2)
+		if ok {
+			las = dt.firsts[after]
+		} else {
+			las = item.lookaheads
+		}
+
+		for _, rule := range dt.rules {
+			if rule.lhs != next {
+				continue
+			}
+
+			new_item, err := NewItem(rule, len(rule.rhss), nil)
+			uc.AssertErr(err, "NewItem(%q, %d, nil)", rule.String(), len(rule.rhss))
+
+			new_item.add_lookaheads(las)
+
+			prev := is.find(new_item)
+			if prev == nil {
+				is.items = append(is.items, new_item)
+				todo = append(todo, new_item)
+			} else if prev.add_lookaheads(las) {
+				todo = append(todo, prev)
+			}
+		}
+	}
+
+	return is
+}
+
+// goto_of is a helper function that computes the state reached from the given state
+// after seeing the given symbol.
+//
+// Parameters:
+//   - is: The state.
+//   - symbol: The symbol.
+//
+// Returns:
+//   - *item_set: The new state. Nil if no item expects the symbol.
+func (dt *DecisionTable[T]) goto_of(is *item_set[T], symbol T) *item_set[T] {
+	var kernel []*Item[T]
+
+	for _, item := range is.items {
+		next, ok := item.GetRhsRelative(-1)
+		if !ok || next != symbol {
+			continue
+		}
+
+		new_item, err := NewItem(item.rule, item.pos-1, nil)
+		uc.AssertErr(err, "NewItem(%q, %d, nil)", item.rule.String(), item.pos-1)
+
+		new_item.add_lookaheads(item.lookaheads)
+
+		kernel = append(kernel, new_item)
+	}
+
+	if len(kernel) == 0 {
+		return nil
+	}
+
+	return dt.closure(kernel)
+}
+
+// make_states is a helper function that builds the canonical collection of LR(1) states.
+//
+// Returns:
+//   - error: An error if the grammar has no start rule.
+func (dt *DecisionTable[T]) make_states() error {
+	var kernel []*Item[T]
+
+	for _, rule := range dt.rules {
+		if !rule.rhss[0].IsAcceptSymbol() {
+			continue
+		}
+
+		if len(kernel) > 0 && kernel[0].GetLhs() != rule.lhs {
+			return fmt.Errorf("rules ending with an accept symbol must have the same left hand side, got %q and %q",
+				kernel[0].GetLhs().String(), rule.lhs.String())
+		}
+
+		item, err := NewItem(rule, len(rule.rhss), nil)
+		uc.AssertErr(err, "NewItem(%q, %d, nil)", rule.String(), len(rule.rhss))
+
+		kernel = append(kernel, item)
+	}
+
+	if len(kernel) == 0 {
+		return errors.New("no rule ends with an accept symbol")
+	}
+
+	dt.states = []*item_set[T]{dt.closure(kernel)}
+
+	for i := 0; i < len(dt.states); i++ {
+		is := dt.states[i]
+
+		for _, symbol := range dt.symbols {
+			next := dt.goto_of(is, symbol)
+			if next == nil {
+				continue
+			}
+
+			idx := slices.IndexFunc(dt.states, next.equals)
+			if idx == -1 {
+				idx = len(dt.states)
+				dt.states = append(dt.states, next)
+			}
+
+			is.gotos[symbol] = idx
+		}
+	}
+
+	return nil
+}
+
+// is_same_action is a helper function that checks whether two actions do the same thing.
+//
+// Parameters:
+//   - a: The first action.
+//   - b: The second action.
+//
+// Returns:
+//   - bool: True if the actions are the same. False otherwise.
+func is_same_action[T TokenTyper](a, b Actioner[T]) bool {
+	switch a := a.(type) {
+	case *ActShift[T]:
+		_, ok := b.(*ActShift[T])
+		return ok
+	case *ActReduce[T]:
+		b, ok := b.(*ActReduce[T])
+		return ok && a.rule == b.rule
+	case *ActAccept[T]:
+		b, ok := b.(*ActAccept[T])
+		return ok && a.rule == b.rule
+	default:
+		return false
+	}
+}
+
+// make_actions is a helper function that fills the actions of every state.
+//
+// Returns:
+//   - error: An error of type *ErrConflict if two items of the same state require
+//     different actions.
+func (dt *DecisionTable[T]) make_actions() error {
+	for i, is := range dt.states {
+		owners := make(map[T]*Item[T])
+		var end_owner *Item[T]
+
+		var conflict *ErrConflict[T]
+
+		for _, item := range is.items {
+			next, ok := item.GetRhsRelative(-1)
+			if ok {
+				if !next.IsTerminal() {
+					continue
+				}
+
+				prev := is.set_action(next, item, owners)
+				if prev != nil {
+					conflict = add_conflict(conflict, prev, item, &next)
+				}
+
+				continue
+			}
+
+			if len(item.lookaheads) == 0 {
+				if end_owner == nil {
+					is.end = item.action
+					end_owner = item
+				} else if !is_same_action(is.end, item.action) {
+					conflict = add_conflict(conflict, end_owner, item, nil)
+				}
+
+				continue
+			}
+
+			for _, la := range item.lookaheads {
+				prev := is.set_action(la, item, owners)
+				if prev != nil {
+					conflict = add_conflict(conflict, prev, item, &la)
+				}
+			}
+		}
+
+		if conflict != nil {
+			return fmt.Errorf("in state %d: %w", i, conflict)
+		}
+	}
+
+	return nil
+}
+
+// set_action is a helper function that sets the action of the state for the given lookahead.
+//
+// Parameters:
+//   - la: The lookahead.
+//   - item: The item that requires the action.
+//   - owners: The items that set the action of each lookahead so far.
+//
+// Returns:
+//   - *Item: The item that already set a different action for the lookahead. Nil if there is
+//     no conflict.
+func (is *item_set[T]) set_action(la T, item *Item[T], owners map[T]*Item[T]) *Item[T] {
+	prev, ok := owners[la]
+	if !ok {
+		is.actions[la] = item.action
+		owners[la] = item
+
+		return nil
+	}
+
+	if is_same_action(prev.action, item.action) {
+		return nil
+	}
+
+	return prev
+}
+
+// add_conflict is a helper function that records a conflict between two items. Only the
+// first pair of conflicting rules is recorded; the lookaheads of later conflicts between
+// the same pair are added to it.
+//
+// Parameters:
+//   - conflict: The conflict recorded so far. Nil if there is none.
+//   - prev: The item that set the action first.
+//   - item: The item that requires a different action.
+//   - la: The lookahead of the conflict. Nil if it occurs at the end of the input.
+//
+// Returns:
+//   - *ErrConflict: The recorded conflict. Never returns nil.
+//
+// Shift actions are always reported first; as in "shift/reduce".
+func add_conflict[T TokenTyper](conflict *ErrConflict[T], prev, item *Item[T], la *T) *ErrConflict[T] {
+	if _, ok := item.action.(*ActShift[T]); ok {
+		prev, item = item, prev
+	}
+
+	if conflict == nil {
+		conflict = NewErrConflict(prev.rule, prev.action, item.rule, item.action)
+	} else if !conflict.Rules[0].Equals(prev.rule) || !conflict.Rules[1].Equals(item.rule) {
+		return conflict
+	}
+
+	if la != nil {
+		pos, ok := slices.BinarySearch(conflict.Lookaheads, *la)
+		if !ok {
+			conflict.Lookaheads = slices.Insert(conflict.Lookaheads, pos, *la)
+		}
+	}
+
+	return conflict
+}
+
+// NewDecisionTable creates a new decision table.
+//
+// Parameters:
+//   - grammar: The grammar string.
+//   - f: The function that transforms a field into a token type.
+//
+// Returns:
+//   - *DecisionTable: The new decision table.
+//   - error: An error if the grammar is invalid.
+//
+// The grammar must have one or more rules whose last right hand side is an accept symbol, all
+// of them sharing the same left hand side; which is the start symbol of the grammar.
+func NewDecisionTable[T TokenTyper](grammar string, f StringToTypeFunc[T]) (*DecisionTable[T], error) {
+	if f == nil {
+		return nil, uc.NewErrNilParameter("f")
+	} else if grammar == "" {
+		return nil, uc.NewErrInvalidParameter("grammar", uc.NewErrEmpty(grammar))
+	}
+
+	dt := &DecisionTable[T]{}
+
+	rules, helpers, err := parse_grammar(grammar, f)
+	if err != nil {
+		return nil, err
+	}
+
+	dt.rules = rules
+	dt.helpers = helpers
+	dt.make_symbols()
+
+	err = dt.check_rules()
+	if err != nil {
+		return nil, err
+	}
+
+	dt.make_firsts()
+
+	err = dt.make_states()
+	if err != nil {
+		return nil, err
+	}
+
+	err = dt.make_actions()
+	if err != nil {
+		return nil, err
+	}
+
+	return dt, nil
+}
+
+// Decide is a helper function that decides the next action.
+//
+// Parameters:
+//   - stack: The stack.
+//   - la: The lookahead token.
+//
+// Returns:
+//   - Actioner: The next action.
+//   - error: An error if the input stream is invalid.
+//
+// The elements of the stack are not modified. The states they lead to are cached in the
+// stack so that each element is only walked through once.
+func (dt *DecisionTable[T]) Decide(stack *Stack[T], la *T) (Actioner[T], error) {
+	if stack == nil {
+		return nil, uc.NewErrNilParameter("stack")
+	}
+
+	_, err := dt.walk(stack)
+	if err != nil {
+		return nil, err
+	}
+
+	n := len(stack.elems)
+
+	return dt.decide(stack.state_at(n), top_type(stack, n), la)
+}
+
+// walk is a helper function that computes the states reached after the elements of the
+// stack whose state is not cached yet.
+//
+// Parameters:
+//   - stack: The stack.
+//
+// Returns:
+//   - int: The number of elements, from the bottom, whose state is known.
+//   - error: An error of type *ErrUnexpected if an element does not lead to a state.
+func (dt *DecisionTable[T]) walk(stack *Stack[T]) (int, error) {
+	for len(stack.states) < len(stack.elems) {
+		n := len(stack.states)
+		state := stack.state_at(n)
+		tok := stack.elems[n]
+
+		next, ok := dt.states[state].gotos[tok.Type]
+		if !ok {
+			return n, NewErrUnexpected(&tok.Type, top_type(stack, n), dt.expecteds(state)...)
+		}
+
+		stack.states = append(stack.states, next)
+	}
+
+	return len(stack.elems), nil
+}
+
+// top_type is a helper function that returns the type of the last of the first n
+// elements of the stack.
+//
+// Parameters:
+//   - stack: The stack.
+//   - n: The number of elements.
+//
+// Returns:
+//   - *T: The type. Nil if n is 0.
+func top_type[T TokenTyper](stack *Stack[T], n int) *T {
+	if n == 0 {
+		return nil
+	}
+
+	return &stack.elems[n-1].Type
+}
+
+// decide is a helper function that decides the next action in the given state.
+//
+// Parameters:
+//   - state: The state.
+//   - prev: The type of the last element of the stack. Nil if the stack is empty.
+//   - la: The lookahead token.
+//
+// Returns:
+//   - Actioner: The next action.
+//   - error: An error if the lookahead is not expected.
+func (dt *DecisionTable[T]) decide(state int, prev, la *T) (Actioner[T], error) {
+	is := dt.states[state]
+
+	if la == nil {
+		if is.end == nil {
+			return nil, NewErrUnexpected(nil, prev, dt.expecteds(state)...)
+		}
+
+		return is.end, nil
+	}
+
+	act, ok := is.actions[*la]
+	if !ok {
+		return nil, NewErrUnexpected(la, prev, dt.expecteds(state)...)
+	}
+
+	return act, nil
+}
+
+// can_close is a helper function that checks whether the given state expects a
+// non-terminal with a rule ending with the given terminal.
+//
+// Parameters:
+//   - state: The state.
+//   - closer: The terminal.
+//
+// Returns:
+//   - bool: True if the state expects such a non-terminal. False otherwise.
+func (dt *DecisionTable[T]) can_close(state int, closer T) bool {
+	for symbol := range dt.states[state].gotos {
+		if symbol.IsTerminal() {
+			continue
+		}
+
+		for _, rule := range dt.rules {
+			if rule.lhs == symbol && rule.rhss[0] == closer {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// expecteds is a helper function that returns the terminals expected at the given state.
+//
+// Parameters:
+//   - state: The state.
+//
+// Returns:
+//   - []T: The expected terminals; sorted.
+func (dt *DecisionTable[T]) expecteds(state int) []T {
+	is := dt.states[state]
+
+	expecteds := make([]T, 0, len(is.actions))
+
+	for la := range is.actions {
+		expecteds = append(expecteds, la)
+	}
+
+	slices.Sort(expecteds)
+
+	return expecteds
+}
+
+// start is a helper function that returns the start symbol of the grammar.
+//
+// Returns:
+//   - T: The start symbol.
+//   - bool: True if the grammar has a start symbol. False otherwise.
+func (dt *DecisionTable[T]) start() (T, bool) {
+	for _, rule := range dt.rules {
+		if rule.rhss[0].IsAcceptSymbol() {
+			return rule.lhs, true
+		}
+	}
+
+	return *new(T), false
+}
+
+// IsHelper checks whether the symbol is a helper non-terminal introduced by a repetition
+// of the grammar.
+//
+// Parameters:
+//   - symbol: The symbol to check.
+//
+// Returns:
+//   - bool: True if the symbol is a helper. False otherwise.
+func (dt *DecisionTable[T]) IsHelper(symbol T) bool {
+	_, ok := slices.BinarySearch(dt.helpers, symbol)
+	return ok
+}
+
+// Flatten replaces, in place, every helper token of the tree with its children so that
+// the elements of a repetition become direct children of the token that contains it.
+//
+// For instance, with the rule "Source = Elem { Elem } EOF .", the tree
+//
+//	Source
+//	 ├── Elem
+//	 ├── Source1
+//	 │    ├── Source1
+//	 │    │    └── Elem
+//	 │    └── Elem
+//	 └── EOF
+//
+// becomes
+//
+//	Source
+//	 ├── Elem
+//	 ├── Elem
+//	 ├── Elem
+//	 └── EOF
+//
+// Parameters:
+//   - root: The root of the tree.
+//
+// Does nothing if the root is nil or a leaf. If the root itself is a helper, only its
+// descendants are flattened.
+//
+// Each token is visited once; so a chain of n helpers, as built from a repetition of n
+// elements, is flattened in O(n).
+func (dt *DecisionTable[T]) Flatten(root *Token[T]) {
+	if root == nil {
+		return
+	}
+
+	children, ok := root.Data.([]*Token[T])
+	if !ok {
+		return
+	}
+
+	var new_children []*Token[T]
+
+	// todo holds the tokens left to visit; the next one last. Helpers are replaced by
+	// their children without recursion since their chains are as long as the input.
+	todo := slices.Clone(children)
+	slices.Reverse(todo)
+
+	for len(todo) > 0 {
+		child := todo[len(todo)-1]
+		todo = todo[:len(todo)-1]
+
+		sub_children, ok := child.Data.([]*Token[T])
+
+		if ok && dt.IsHelper(child.Type) {
+			for i := len(sub_children) - 1; i >= 0; i-- {
+				todo = append(todo, sub_children[i])
+			}
+
+			continue
+		}
+
+		dt.Flatten(child)
+
+		new_children = append(new_children, child)
+	}
+
+	root.Data = new_children
+}
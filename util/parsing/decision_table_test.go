@@ -0,0 +1,310 @@
+package parsing
+
+import (
+	"errors"
+	"testing"
+)
+
+type ExprTokenType int
+
+const (
+	EttEOF ExprTokenType = iota
+	EttNum
+	EttPlus
+
+	EttSource
+	EttExpr
+	EttExpr1
+)
+
+func (t ExprTokenType) IsAcceptSymbol() bool {
+	return t == EttEOF
+}
+
+func (t ExprTokenType) IsTerminal() bool {
+	return t <= EttPlus
+}
+
+func (t ExprTokenType) String() string {
+	return [...]string{
+		"end of file",
+		"number",
+		"plus",
+		"source",
+		"expression",
+		"expression (I)",
+	}[t]
+}
+
+func (t ExprTokenType) GoString() string {
+	return [...]string{
+		"EttEOF",
+		"EttNum",
+		"EttPlus",
+		"EttSource",
+		"EttExpr",
+		"EttExpr1",
+	}[t]
+}
+
+func ExprSttFunc(field string) (ExprTokenType, bool) {
+	switch field {
+	case "EOF":
+		return EttEOF, true
+	case "num":
+		return EttNum, true
+	case "plus":
+		return EttPlus, true
+	case "Source":
+		return EttSource, true
+	case "Expr":
+		return EttExpr, true
+	case "Expr1":
+		return EttExpr1, true
+	default:
+		return 0, false
+	}
+}
+
+const ExprGrammar string = `
+Source = Expr EOF .
+Expr = Expr plus num .
+Expr = num .
+`
+
+// make_expr_tokens is a helper function that creates the tokens for the given types.
+func make_expr_tokens(types ...ExprTokenType) []*Token[ExprTokenType] {
+	tokens := make([]*Token[ExprTokenType], 0, len(types))
+
+	for _, typ := range types {
+		tokens = append(tokens, NewToken(typ, typ.String(), nil))
+	}
+
+	for i := 0; i < len(tokens)-1; i++ {
+		tokens[i].Lookahead = tokens[i+1]
+	}
+
+	return tokens
+}
+
+// run_decisions is a helper function that runs the decision table on the given tokens and
+// returns the names of the actions taken.
+func run_decisions(dt *DecisionTable[ExprTokenType], tokens []*Token[ExprTokenType]) ([]string, error) {
+	stack := NewStack[ExprTokenType]()
+
+	var actions []string
+
+	for {
+		var la *ExprTokenType
+
+		if len(tokens) > 0 {
+			la = &tokens[0].Type
+		}
+
+		act, err := dt.Decide(stack, la)
+		if err != nil {
+			return actions, err
+		}
+
+		actions = append(actions, act.String())
+
+		switch act := act.(type) {
+		case *ActShift[ExprTokenType]:
+			stack.Push(tokens[0])
+			tokens = tokens[1:]
+		case *ActReduce[ExprTokenType], *ActAccept[ExprTokenType]:
+			iter := act.Iterator()
+
+			for {
+				_, err := iter.Consume()
+				if err != nil {
+					break
+				}
+
+				stack.Pop()
+			}
+
+			stack.Accept()
+			stack.Push(NewToken(act.GetLHS(), "", nil))
+
+			if _, ok := act.(*ActAccept[ExprTokenType]); ok {
+				return actions, nil
+			}
+		}
+	}
+}
+
+func TestNewDecisionTable(t *testing.T) {
+	dt, err := NewDecisionTable(ExprGrammar, ExprSttFunc)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	tokens := make_expr_tokens(EttNum, EttPlus, EttNum, EttEOF)
+
+	actions, err := run_decisions(dt, tokens)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	expecteds := []string{"shift", "reduce", "shift", "shift", "reduce", "shift", "accept"}
+
+	if len(actions) != len(expecteds) {
+		t.Fatalf("expected %v, got %v", expecteds, actions)
+	}
+
+	for i, expected := range expecteds {
+		if actions[i] != expected {
+			t.Errorf("expected %q at %d, got %q instead", expected, i, actions[i])
+		}
+	}
+}
+
+func TestDecideUnexpected(t *testing.T) {
+	dt, err := NewDecisionTable(ExprGrammar, ExprSttFunc)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	tokens := make_expr_tokens(EttNum, EttPlus, EttPlus, EttEOF)
+
+	_, err = run_decisions(dt, tokens)
+	if err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+
+	expected := "expected \"number\" after \"plus\", got \"plus\" instead"
+
+	if err.Error() != expected {
+		t.Errorf("expected %q, got %q", expected, err.Error())
+	}
+}
+
+func TestNewDecisionTableNoStart(t *testing.T) {
+	_, err := NewDecisionTable("Expr = num .\n", ExprSttFunc)
+	if err == nil {
+		t.Errorf("expected error, got nil")
+	}
+}
+
+func TestNewDecisionTableConflict(t *testing.T) {
+	const grammar string = `
+Source = Expr EOF .
+Expr = Expr plus Expr .
+Expr = num .
+`
+
+	_, err := NewDecisionTable(grammar, ExprSttFunc)
+	if err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+
+	var conflict *ErrConflict[ExprTokenType]
+
+	ok := errors.As(err, &conflict)
+	if !ok {
+		t.Fatalf("expected *ErrConflict, got %T instead", err)
+	}
+
+	if len(conflict.Lookaheads) != 1 || conflict.Lookaheads[0] != EttPlus {
+		t.Errorf("expected lookaheads [EttPlus], got %v", conflict.Lookaheads)
+	}
+
+	rules := []string{conflict.Rules[0].String(), conflict.Rules[1].String()}
+
+	if rules[0] != rules[1] || rules[0] != "expression = expression plus expression ." {
+		t.Errorf("unexpected rules %q", rules)
+	}
+
+	expected := "shift/reduce conflict between \"expression = expression plus expression .\" and \"expression = expression plus expression .\" on \"plus\""
+
+	if conflict.Error() != expected {
+		t.Errorf("expected %q, got %q", expected, conflict.Error())
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	const grammar string = `
+Source = Expr EOF .
+Expr = num { plus num } .
+`
+
+	dt, err := NewDecisionTable(grammar, ExprSttFunc)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	if !dt.IsHelper(EttExpr1) {
+		t.Fatalf("expected EttExpr1 to be a helper")
+	}
+
+	nums := make_expr_tokens(EttNum, EttPlus, EttNum, EttPlus, EttNum)
+
+	inner := NewToken(EttExpr1, []*Token[ExprTokenType]{nums[1], nums[2]}, nil)
+	outer := NewToken(EttExpr1, []*Token[ExprTokenType]{inner, nums[3], nums[4]}, nil)
+	root := NewToken(EttExpr, []*Token[ExprTokenType]{nums[0], outer}, nil)
+
+	dt.Flatten(root)
+
+	children, ok := root.Data.([]*Token[ExprTokenType])
+	if !ok {
+		t.Fatalf("expected children, got %T", root.Data)
+	}
+
+	if len(children) != len(nums) {
+		t.Fatalf("expected %d children, got %d", len(nums), len(children))
+	}
+
+	for i, child := range children {
+		if child != nums[i] {
+			t.Errorf("expected %s at %d, got %s", nums[i].GoString(), i, child.GoString())
+		}
+	}
+}
+
+// make_expr_chain is a helper function that creates an expression whose repetition holds n
+// numbers; as a chain of n helpers, like the parser builds it.
+func make_expr_chain(n int) *Token[ExprTokenType] {
+	helper := NewToken(EttExpr1, []*Token[ExprTokenType]{NewToken(EttPlus, "+", nil), NewToken(EttNum, "1", nil)}, nil)
+
+	for i := 1; i < n; i++ {
+		helper = NewToken(EttExpr1, []*Token[ExprTokenType]{helper, NewToken(EttPlus, "+", nil), NewToken(EttNum, "1", nil)}, nil)
+	}
+
+	return NewToken(EttExpr, []*Token[ExprTokenType]{NewToken(EttNum, "1", nil), helper}, nil)
+}
+
+func TestFlattenLarge(t *testing.T) {
+	const grammar string = `
+Source = Expr EOF .
+Expr = num { plus num } .
+`
+
+	dt, err := NewDecisionTable(grammar, ExprSttFunc)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	// Quadratic in n if the helpers are copied at every level.
+	const n int = 100000
+
+	root := make_expr_chain(n)
+
+	dt.Flatten(root)
+
+	children, ok := root.Data.([]*Token[ExprTokenType])
+	if !ok || len(children) != 2*n+1 {
+		t.Fatalf("expected %d children, got %v", 2*n+1, len(children))
+	}
+
+	for i, child := range children {
+		expected := EttNum
+		if i%2 == 1 {
+			expected = EttPlus
+		}
+
+		if child.Type != expected {
+			t.Fatalf("expected %s at %d, got %s", expected.GoString(), i, child.Type.GoString())
+		}
+	}
+}
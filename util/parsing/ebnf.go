@@ -0,0 +1,353 @@
+package parsing
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ebnf_kind is the kind of an EBNF node.
+type ebnf_kind int
+
+const (
+	// ebnf_symbol is a single symbol.
+	ebnf_symbol ebnf_kind = iota
+
+	// ebnf_sequence is a sequence of terms.
+	ebnf_sequence
+
+	// ebnf_alternation is a list of alternative sequences. a | b
+	ebnf_alternation
+
+	// ebnf_option is an optional group. [ a ]
+	ebnf_option
+
+	// ebnf_repetition is a group repeated zero or more times. { a }
+	ebnf_repetition
+)
+
+// ebnf_node is a node of the right hand side of a rule before desugaring.
+type ebnf_node struct {
+	// kind is the kind of the node.
+	kind ebnf_kind
+
+	// sym is the symbol of the node if its kind is ebnf_symbol. Otherwise, only its position
+	// is set.
+	sym raw_symbol
+
+	// children are the children of the node.
+	children []*ebnf_node
+}
+
+// opener returns the character that opens the group.
+//
+// Returns:
+//   - rune: The opening character. 0 if the node is not a group.
+func (n *ebnf_node) opener() rune {
+	switch n.kind {
+	case ebnf_option:
+		return '['
+	case ebnf_repetition:
+		return '{'
+	default:
+		return 0
+	}
+}
+
+// closer returns the character that closes the group.
+//
+// Returns:
+//   - rune: The closing character. 0 if the node is not a group.
+func (n *ebnf_node) closer() rune {
+	switch n.kind {
+	case ebnf_option:
+		return ']'
+	case ebnf_repetition:
+		return '}'
+	default:
+		return 0
+	}
+}
+
+// empty_error is a helper function that creates the error for an empty sequence.
+//
+// Parameters:
+//   - rule: The rule the sequence belongs to.
+//   - is_whole: True if the sequence is the whole right hand side of the rule.
+//
+// Returns:
+//   - *ErrGrammar: The error. Never returns nil.
+func (n *ebnf_node) empty_error(rule *ebnf_rule, is_whole bool) *ErrGrammar {
+	if is_whole {
+		return NewErrGrammar(n.sym.line, n.sym.column, fmt.Errorf("rule %q has an empty right hand side", rule.lhs.name))
+	}
+
+	return NewErrGrammar(n.sym.line, n.sym.column, fmt.Errorf("empty alternative in rule %q", rule.lhs.name))
+}
+
+// ebnf_rule is a rule before desugaring.
+type ebnf_rule struct {
+	// lhs is the left hand side.
+	lhs raw_symbol
+
+	// body is the right hand side. Its kind is always ebnf_alternation.
+	body *ebnf_node
+}
+
+// desugarer transforms EBNF rules into plain rules.
+//
+// Options are expanded into one rule with and one rule without the optional group;
+// alternatives are expanded into one rule each; and repetitions are replaced by a
+// helper non-terminal H defined as:
+//
+//	H = a .
+//	H = H a .
+//
+// The helper is named after the left hand side of the rule that introduced it followed
+// by a number. (e.g., Source1, Source2, ...)
+type desugarer struct {
+	// names are the left hand sides written in the grammar plus the helpers created so far.
+	names map[string]bool
+
+	// counters are the last number used for each left hand side.
+	counters map[string]int
+
+	// helpers are the helper names indexed by the body of their repetition.
+	helpers map[string]string
+
+	// pending are the rules of the helpers created while expanding the current rule.
+	pending []*raw_rule
+
+	// helper_names are the names of the helpers; in the order they were created.
+	helper_names []string
+}
+
+// new_desugarer creates a new desugarer.
+//
+// Parameters:
+//   - rules: The rules to desugar.
+//
+// Returns:
+//   - *desugarer: The new desugarer. Never returns nil.
+func new_desugarer(rules []*ebnf_rule) *desugarer {
+	names := make(map[string]bool)
+
+	for _, rule := range rules {
+		names[rule.lhs.name] = true
+	}
+
+	return &desugarer{
+		names:    names,
+		counters: make(map[string]int),
+		helpers:  make(map[string]string),
+	}
+}
+
+// helper_name is a helper function that returns a new, unused, name for a helper.
+//
+// Parameters:
+//   - base: The left hand side of the rule that introduced the helper.
+//
+// Returns:
+//   - string: The name of the helper.
+func (d *desugarer) helper_name(base string) string {
+	for {
+		d.counters[base]++
+
+		name := base + strconv.Itoa(d.counters[base])
+
+		if !d.names[name] {
+			d.names[name] = true
+
+			return name
+		}
+	}
+}
+
+// expand is a helper function that expands a node into all the sequences of symbols it
+// can match.
+//
+// Parameters:
+//   - rule: The rule the node belongs to.
+//   - n: The node to expand.
+//
+// Returns:
+//   - [][]raw_symbol: The sequences. An empty sequence means that the node can match nothing.
+//   - error: An error of type *ErrGrammar if a repetition can match nothing.
+func (d *desugarer) expand(rule *ebnf_rule, n *ebnf_node) ([][]raw_symbol, error) {
+	switch n.kind {
+	case ebnf_symbol:
+		return [][]raw_symbol{{n.sym}}, nil
+	case ebnf_sequence:
+		seqs := [][]raw_symbol{nil}
+
+		for _, child := range n.children {
+			sub_seqs, err := d.expand(rule, child)
+			if err != nil {
+				return nil, err
+			}
+
+			var new_seqs [][]raw_symbol
+
+			for _, seq := range seqs {
+				for _, sub_seq := range sub_seqs {
+					new_seq := make([]raw_symbol, 0, len(seq)+len(sub_seq))
+					new_seq = append(new_seq, seq...)
+					new_seq = append(new_seq, sub_seq...)
+
+					new_seqs = append(new_seqs, new_seq)
+				}
+			}
+
+			seqs = new_seqs
+		}
+
+		return seqs, nil
+	case ebnf_alternation:
+		var seqs [][]raw_symbol
+
+		for _, child := range n.children {
+			sub_seqs, err := d.expand(rule, child)
+			if err != nil {
+				return nil, err
+			}
+
+			seqs = append(seqs, sub_seqs...)
+		}
+
+		return seqs, nil
+	case ebnf_option:
+		seqs, err := d.expand(rule, n.children[0])
+		if err != nil {
+			return nil, err
+		}
+
+		return append(seqs, nil), nil
+	case ebnf_repetition:
+		seqs, err := d.expand(rule, n.children[0])
+		if err != nil {
+			return nil, err
+		}
+
+		values := make([]string, 0, len(seqs))
+
+		for _, seq := range seqs {
+			if len(seq) == 0 {
+				return nil, NewErrGrammar(n.sym.line, n.sym.column, fmt.Errorf("repetition in rule %q may match nothing", rule.lhs.name))
+			}
+
+			values = append(values, symbols_string(seq))
+		}
+
+		key := strings.Join(values, " | ")
+
+		name, ok := d.helpers[key]
+		if !ok {
+			name = d.helper_name(rule.lhs.name)
+			d.helpers[key] = name
+			d.helper_names = append(d.helper_names, name)
+
+			d.add_helper(name, n, seqs)
+		}
+
+		helper := raw_symbol{
+			name:      name,
+			line:      n.sym.line,
+			column:    n.sym.column,
+			is_helper: true,
+		}
+
+		return [][]raw_symbol{nil, {helper}}, nil
+	default:
+		return nil, NewErrGrammar(n.sym.line, n.sym.column, fmt.Errorf("unknown EBNF node kind %d", n.kind))
+	}
+}
+
+// add_helper is a helper function that adds the rules of a helper to the pending rules.
+//
+// Parameters:
+//   - name: The name of the helper.
+//   - n: The repetition node.
+//   - seqs: The sequences of the body of the repetition.
+func (d *desugarer) add_helper(name string, n *ebnf_node, seqs [][]raw_symbol) {
+	lhs := raw_symbol{
+		name:      name,
+		line:      n.sym.line,
+		column:    n.sym.column,
+		is_helper: true,
+	}
+
+	for _, seq := range seqs {
+		d.pending = append(d.pending, &raw_rule{
+			lhs:  lhs,
+			rhss: seq,
+		})
+	}
+
+	for _, seq := range seqs {
+		rhss := make([]raw_symbol, 0, len(seq)+1)
+		rhss = append(rhss, lhs)
+		rhss = append(rhss, seq...)
+
+		d.pending = append(d.pending, &raw_rule{
+			lhs:  lhs,
+			rhss: rhss,
+		})
+	}
+}
+
+// desugar is a helper function that transforms the EBNF rules into plain rules.
+//
+// Parameters:
+//   - rules: The rules to desugar.
+//
+// Returns:
+//   - []*raw_rule: The plain rules. Each rule is followed by the rules of the helpers it
+//     introduced.
+//   - []string: The names of the helpers.
+//   - error: An error of type *ErrGrammar if a rule or a repetition can match nothing.
+func desugar(rules []*ebnf_rule) ([]*raw_rule, []string, error) {
+	d := new_desugarer(rules)
+
+	var raws []*raw_rule
+
+	for _, rule := range rules {
+		seqs, err := d.expand(rule, rule.body)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, seq := range seqs {
+			if len(seq) == 0 {
+				return nil, nil, NewErrGrammar(rule.lhs.line, rule.lhs.column, fmt.Errorf("rule %q may have an empty right hand side", rule.lhs.name))
+			}
+
+			raws = append(raws, &raw_rule{
+				lhs:  rule.lhs,
+				rhss: seq,
+			})
+		}
+
+		raws = append(raws, d.pending...)
+		d.pending = d.pending[:0]
+	}
+
+	return raws, d.helper_names, nil
+}
+
+// symbols_string is a helper function that joins the names of the symbols with spaces.
+//
+// Parameters:
+//   - syms: The symbols.
+//
+// Returns:
+//   - string: The joined names.
+func symbols_string(syms []raw_symbol) string {
+	values := make([]string, 0, len(syms))
+
+	for _, sym := range syms {
+		values = append(values, sym.name)
+	}
+
+	return strings.Join(values, " ")
+}
@@ -7,6 +7,23 @@ import (
 	utstr "github.com/PlayerR9/lib_units/strings"
 )
 
+// join_values is a helper function that joins the values with commas; except for the last
+// two values which are joined with the given separator.
+//
+// Parameters:
+//   - values: The values to join.
+//   - last_sep: The separator between the last two values. (e.g., " or ")
+//
+// Returns:
+//   - string: The joined values.
+func join_values(values []string, last_sep string) string {
+	if len(values) <= 1 {
+		return strings.Join(values, "")
+	}
+
+	return strings.Join(values[:len(values)-1], ", ") + last_sep + values[len(values)-1]
+}
+
 // ErrExpected is an error for expected values.
 type ErrExpected[T TokenTyper] struct {
 	// Expecteds is the expected value.
@@ -140,3 +157,335 @@ func NewErrReduce[T TokenTyper](lhs T, expected T, got *T, before *T) *ErrReduce
 		Before:   before,
 	}
 }
+
+// ErrUnexpected is an error for unexpected lookaheads.
+type ErrUnexpected[T TokenTyper] struct {
+	// Expecteds are the expected values.
+	Expecteds []T
+
+	// Got is the actual value. Nil if the end of the input was reached.
+	Got *T
+
+	// After is the value after which the error occurred. Nil if the error occurred at the start of the input.
+	After *T
+}
+
+// Error implements the error interface.
+//
+// Message: "expected {{ .Expecteds }} after {{ .After }}, got {{ .Got }} instead."
+func (e *ErrUnexpected[T]) Error() string {
+	var got string
+
+	if e.Got == nil {
+		got = "nothing"
+	} else {
+		got = strconv.Quote((*e.Got).String())
+	}
+
+	var builder strings.Builder
+
+	builder.WriteString("expected ")
+
+	if len(e.Expecteds) == 0 {
+		builder.WriteString("nothing")
+	} else {
+		values := make([]string, 0, len(e.Expecteds))
+
+		for _, v := range e.Expecteds {
+			values = append(values, strconv.Quote(v.String()))
+		}
+
+		builder.WriteString(join_values(values, " or "))
+	}
+
+	if e.After != nil {
+		builder.WriteString(" after ")
+		builder.WriteString(strconv.Quote((*e.After).String()))
+	}
+
+	builder.WriteString(", got ")
+	builder.WriteString(got)
+	builder.WriteString(" instead")
+
+	return builder.String()
+}
+
+// NewErrUnexpected creates a new error.
+//
+// Parameters:
+//   - got: The actual value.
+//   - after: The value after which the error occurred.
+//   - expecteds: The expected values.
+//
+// Returns:
+//   - *ErrUnexpected[T]: The error. Never returns nil.
+func NewErrUnexpected[T TokenTyper](got *T, after *T, expecteds ...T) *ErrUnexpected[T] {
+	return &ErrUnexpected[T]{
+		Expecteds: expecteds,
+		Got:       got,
+		After:     after,
+	}
+}
+
+// ErrConflict is an error that occurs when two rules require different actions
+// on the same lookahead.
+type ErrConflict[T TokenTyper] struct {
+	// Rules are the conflicting rules.
+	Rules [2]*Rule[T]
+
+	// Actions are the actions required by each rule.
+	Actions [2]Actioner[T]
+
+	// Lookaheads are the lookaheads on which the rules conflict. Empty if the conflict
+	// occurs at the end of the input.
+	Lookaheads []T
+}
+
+// Error implements the error interface.
+//
+// Message: "{{ .Actions[0] }}/{{ .Actions[1] }} conflict between {{ .Rules[0] }} and {{ .Rules[1] }} on {{ .Lookaheads }}"
+func (e *ErrConflict[T]) Error() string {
+	var builder strings.Builder
+
+	builder.WriteString(e.Actions[0].String())
+	builder.WriteRune('/')
+	builder.WriteString(e.Actions[1].String())
+	builder.WriteString(" conflict between ")
+	builder.WriteString(strconv.Quote(e.Rules[0].String()))
+	builder.WriteString(" and ")
+	builder.WriteString(strconv.Quote(e.Rules[1].String()))
+
+	if len(e.Lookaheads) == 0 {
+		builder.WriteString(" at the end of the input")
+	} else {
+		values := make([]string, 0, len(e.Lookaheads))
+
+		for _, la := range e.Lookaheads {
+			values = append(values, strconv.Quote(la.String()))
+		}
+
+		builder.WriteString(" on ")
+		builder.WriteString(join_values(values, " and "))
+	}
+
+	return builder.String()
+}
+
+// NewErrConflict creates a new error.
+//
+// Parameters:
+//   - first: The first rule.
+//   - first_act: The action required by the first rule.
+//   - second: The second rule.
+//   - second_act: The action required by the second rule.
+//
+// Returns:
+//   - *ErrConflict[T]: The error. Never returns nil.
+func NewErrConflict[T TokenTyper](first *Rule[T], first_act Actioner[T], second *Rule[T], second_act Actioner[T]) *ErrConflict[T] {
+	return &ErrConflict[T]{
+		Rules:   [2]*Rule[T]{first, second},
+		Actions: [2]Actioner[T]{first_act, second_act},
+	}
+}
+
+// ErrGrammar is an error that occurs while parsing a grammar.
+type ErrGrammar struct {
+	// Line is the line of the error. (1-indexed)
+	Line int
+
+	// Column is the column of the error. (1-indexed)
+	Column int
+
+	// Reason is the reason of the error.
+	Reason error
+}
+
+// Error implements the error interface.
+//
+// Message: "{{ .Line }}:{{ .Column }}: {{ .Reason }}"
+func (e *ErrGrammar) Error() string {
+	var builder strings.Builder
+
+	builder.WriteString(strconv.Itoa(e.Line))
+	builder.WriteRune(':')
+	builder.WriteString(strconv.Itoa(e.Column))
+	builder.WriteString(": ")
+
+	if e.Reason == nil {
+		builder.WriteString("invalid grammar")
+	} else {
+		builder.WriteString(e.Reason.Error())
+	}
+
+	return builder.String()
+}
+
+// Unwrap returns the reason of the error.
+//
+// Returns:
+//   - error: The reason of the error.
+func (e *ErrGrammar) Unwrap() error {
+	return e.Reason
+}
+
+// NewErrGrammar creates a new error.
+//
+// Parameters:
+//   - line: The line of the error.
+//   - column: The column of the error.
+//   - reason: The reason of the error.
+//
+// Returns:
+//   - *ErrGrammar: The error. Never returns nil.
+func NewErrGrammar(line, column int, reason error) *ErrGrammar {
+	return &ErrGrammar{
+		Line:   line,
+		Column: column,
+		Reason: reason,
+	}
+}
+
+// ErrSyntax is an error that occurs at a position of the input of a lexer or a parser.
+type ErrSyntax struct {
+	// Index is the index of the token in the input. (0-indexed) It is the length of the
+	// input if the error occurs at the end of the input.
+	Index int
+
+	// Pos is the position of the error. Its line is 0 if unknown.
+	Pos Position
+
+	// Reason is the reason of the error.
+	Reason error
+
+	// File is the name of the source file. Empty if unknown.
+	File string
+
+	// Snippet is the line of the source where the error occurs. Empty if unknown.
+	Snippet string
+}
+
+// Error implements the error interface.
+//
+// Message:
+//
+//	"{{ .File }}:{{ .Pos.Line }}:{{ .Pos.Column }}: {{ .Reason }}
+//		{{ .Snippet }}
+//		   ^"
+//
+// The file and the snippet are omitted when unknown. If the position is unknown, the
+// message is "at token {{ .Index }}: {{ .Reason }}" instead.
+func (e *ErrSyntax) Error() string {
+	var builder strings.Builder
+
+	if e.Pos.IsValid() {
+		if e.File != "" {
+			builder.WriteString(e.File)
+			builder.WriteRune(':')
+		}
+
+		builder.WriteString(e.Pos.String())
+	} else {
+		builder.WriteString("at token ")
+		builder.WriteString(strconv.Itoa(e.Index))
+	}
+
+	builder.WriteString(": ")
+
+	if e.Reason == nil {
+		builder.WriteString("syntax error")
+	} else {
+		builder.WriteString(e.Reason.Error())
+	}
+
+	if e.Snippet == "" || !e.Pos.IsValid() {
+		return builder.String()
+	}
+
+	builder.WriteString("\n\t")
+	builder.WriteString(e.Snippet)
+	builder.WriteString("\n\t")
+
+	// Keep the tabs of the snippet so that the caret lines up.
+	for i, c := range []rune(e.Snippet) {
+		if i >= e.Pos.Column-1 {
+			break
+		}
+
+		if c == '\t' {
+			builder.WriteRune('\t')
+		} else {
+			builder.WriteRune(' ')
+		}
+	}
+
+	builder.WriteRune('^')
+
+	return builder.String()
+}
+
+// Unwrap returns the reason of the error.
+//
+// Returns:
+//   - error: The reason of the error.
+func (e *ErrSyntax) Unwrap() error {
+	return e.Reason
+}
+
+// SetSource sets the file and the snippet of the error.
+//
+// Parameters:
+//   - file: The name of the source file. Empty if unknown.
+//   - source: The whole source the position refers to.
+func (e *ErrSyntax) SetSource(file, source string) {
+	e.File = file
+
+	if !e.Pos.IsValid() {
+		return
+	}
+
+	lines := strings.Split(source, "\n")
+	if e.Pos.Line > len(lines) {
+		return
+	}
+
+	e.Snippet = strings.TrimSuffix(lines[e.Pos.Line-1], "\r")
+}
+
+// NewErrSyntax creates a new error.
+//
+// Parameters:
+//   - index: The index of the token.
+//   - pos: The position of the error.
+//   - reason: The reason of the error.
+//
+// Returns:
+//   - *ErrSyntax: The error. Never returns nil.
+func NewErrSyntax(index int, pos Position, reason error) *ErrSyntax {
+	return &ErrSyntax{
+		Index:  index,
+		Pos:    pos,
+		Reason: reason,
+	}
+}
+
+// SetSource calls ErrSyntax.SetSource on every *ErrSyntax of the tree of the error; including
+// those joined with errors.Join.
+//
+// Parameters:
+//   - err: The error.
+//   - file: The name of the source file. Empty if unknown.
+//   - source: The whole source the positions refer to.
+func SetSource(err error, file, source string) {
+	switch e := err.(type) {
+	case nil:
+		return
+	case *ErrSyntax:
+		e.SetSource(file, source)
+	case interface{ Unwrap() []error }:
+		for _, sub := range e.Unwrap() {
+			SetSource(sub, file, source)
+		}
+	case interface{ Unwrap() error }:
+		SetSource(e.Unwrap(), file, source)
+	}
+}
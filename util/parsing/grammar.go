@@ -0,0 +1,674 @@
+package parsing
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	uc "github.com/PlayerR9/lib_units/common"
+)
+
+// raw_symbol is a symbol of a rule as it appears in the grammar.
+type raw_symbol struct {
+	// name is the name of the symbol.
+	name string
+
+	// line is the line of the symbol. (1-indexed)
+	line int
+
+	// column is the column of the symbol. (1-indexed)
+	column int
+
+	// is_helper is true if the symbol was introduced by a repetition.
+	is_helper bool
+}
+
+// raw_rule is a rule as it appears in the grammar; before its symbols are resolved.
+type raw_rule struct {
+	// lhs is the left hand side.
+	lhs raw_symbol
+
+	// rhss are the right hand sides; in the order they appear in the grammar.
+	rhss []raw_symbol
+}
+
+// String implements the fmt.Stringer interface.
+func (r *raw_rule) String() string {
+	var builder strings.Builder
+
+	builder.WriteString(r.lhs.name)
+	builder.WriteString(" = ")
+	builder.WriteString(symbols_string(r.rhss))
+	builder.WriteString(" .")
+
+	return builder.String()
+}
+
+// grammar_parser is a parser for the grammar language.
+//
+// Here's the EBNF of the grammar language:
+//
+//	Grammar = Rule { Rule } .
+//	Rule = identifier "=" Alternatives "." .
+//	Alternatives = Sequence { "|" Sequence } .
+//	Sequence = Term { Term } .
+//	Term = identifier | "{" Alternatives "}" | "[" Alternatives "]" .
+//	identifier = letter { letter | digit | "_" } .
+type grammar_parser struct {
+	// chars is the input stream.
+	chars []rune
+
+	// at is the current position in the input stream.
+	at int
+
+	// line is the current line. (1-indexed)
+	line int
+
+	// column is the current column. (1-indexed)
+	column int
+}
+
+// new_grammar_parser creates a new grammar parser.
+//
+// Parameters:
+//   - str: The grammar string.
+//
+// Returns:
+//   - *grammar_parser: The new grammar parser.
+//   - error: An error of type *ErrGrammar if the string is not valid utf-8.
+func new_grammar_parser(str string) (*grammar_parser, error) {
+	gp := &grammar_parser{
+		line:   1,
+		column: 1,
+	}
+
+	line, column := 1, 1
+
+	for len(str) > 0 {
+		c, size := utf8.DecodeRuneInString(str)
+		if c == utf8.RuneError {
+			return nil, NewErrGrammar(line, column, errors.New("invalid utf-8 encoding"))
+		}
+
+		gp.chars = append(gp.chars, c)
+		str = str[size:]
+
+		if c == '\n' {
+			line++
+			column = 1
+		} else {
+			column++
+		}
+	}
+
+	return gp, nil
+}
+
+// peek is a helper function that returns the next rune without consuming it.
+//
+// Returns:
+//   - rune: The next rune.
+//   - bool: True if there is a next rune, false otherwise.
+func (gp *grammar_parser) peek() (rune, bool) {
+	if gp.at >= len(gp.chars) {
+		return utf8.RuneError, false
+	}
+
+	return gp.chars[gp.at], true
+}
+
+// next is a helper function that consumes the next rune.
+//
+// Returns:
+//   - rune: The consumed rune.
+//   - bool: True if there was a next rune, false otherwise.
+func (gp *grammar_parser) next() (rune, bool) {
+	c, ok := gp.peek()
+	if !ok {
+		return c, false
+	}
+
+	gp.at++
+
+	if c == '\n' {
+		gp.line++
+		gp.column = 1
+	} else {
+		gp.column++
+	}
+
+	return c, true
+}
+
+// skip_spaces is a helper function that skips all whitespace characters.
+func (gp *grammar_parser) skip_spaces() {
+	for {
+		c, ok := gp.peek()
+		if !ok || !unicode.IsSpace(c) {
+			break
+		}
+
+		gp.next()
+	}
+}
+
+// lex_identifier is a helper function that lexes an identifier.
+//
+// Returns:
+//   - raw_symbol: The identifier.
+//   - bool: True if an identifier was lexed, false otherwise.
+func (gp *grammar_parser) lex_identifier() (raw_symbol, bool) {
+	sym := raw_symbol{
+		line:   gp.line,
+		column: gp.column,
+	}
+
+	c, ok := gp.peek()
+	if !ok || !unicode.IsLetter(c) {
+		return sym, false
+	}
+
+	var builder strings.Builder
+
+	for {
+		c, ok := gp.peek()
+		if !ok || !(unicode.IsLetter(c) || unicode.IsDigit(c) || c == '_') {
+			break
+		}
+
+		builder.WriteRune(c)
+
+		gp.next()
+	}
+
+	sym.name = builder.String()
+
+	return sym, true
+}
+
+// is_rule_start is a helper function that checks whether the input stream, at the
+// current position, is the start of a new rule; that is, an identifier followed by an
+// equal sign. The input stream is not consumed.
+//
+// Returns:
+//   - bool: True if a new rule starts here, false otherwise.
+func (gp *grammar_parser) is_rule_start() bool {
+	at, line, column := gp.at, gp.line, gp.column
+
+	defer func() {
+		gp.at, gp.line, gp.column = at, line, column
+	}()
+
+	_, ok := gp.lex_identifier()
+	if !ok {
+		return false
+	}
+
+	gp.skip_spaces()
+
+	c, ok := gp.peek()
+
+	return ok && c == '='
+}
+
+// parse_rule is a helper function that parses a single rule.
+//
+// Returns:
+//   - *ebnf_rule: The rule.
+//   - error: An error of type *ErrGrammar if the rule is invalid.
+func (gp *grammar_parser) parse_rule() (*ebnf_rule, error) {
+	lhs, ok := gp.lex_identifier()
+	if !ok {
+		return nil, gp.unexpected("a left hand side")
+	}
+
+	gp.skip_spaces()
+
+	c, ok := gp.peek()
+	if !ok || c != '=' {
+		return nil, gp.unexpected(strconv.Quote("="))
+	}
+
+	gp.next() // consume '='
+
+	rule := &ebnf_rule{
+		lhs: lhs,
+	}
+
+	body, err := gp.parse_alternatives(rule, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	gp.next() // consume '.'
+
+	rule.body = body
+
+	return rule, nil
+}
+
+// parse_alternatives is a helper function that parses alternatives separated by "|" up to the
+// closing character; which is not consumed.
+//
+// Parameters:
+//   - rule: The rule being parsed.
+//   - open: The opening element. Nil if the alternatives are the right hand side of the rule.
+//
+// Returns:
+//   - *ebnf_node: The alternatives.
+//   - error: An error of type *ErrGrammar if the alternatives are invalid.
+func (gp *grammar_parser) parse_alternatives(rule *ebnf_rule, open *ebnf_node) (*ebnf_node, error) {
+	alt := &ebnf_node{
+		kind: ebnf_alternation,
+		sym: raw_symbol{
+			line:   gp.line,
+			column: gp.column,
+		},
+	}
+
+	for {
+		seq, err := gp.parse_sequence(rule, open, len(alt.children) > 0)
+		if err != nil {
+			return nil, err
+		}
+
+		alt.children = append(alt.children, seq)
+
+		c, _ := gp.peek()
+		if c != '|' {
+			return alt, nil
+		}
+
+		gp.next() // consume '|'
+	}
+}
+
+// parse_sequence is a helper function that parses a sequence of terms up to either "|" or
+// the closing character; none of which is consumed.
+//
+// Parameters:
+//   - rule: The rule being parsed.
+//   - open: The opening element. Nil if the sequence belongs to the right hand side of the rule.
+//   - after_bar: True if the sequence follows a "|".
+//
+// Returns:
+//   - *ebnf_node: The sequence.
+//   - error: An error of type *ErrGrammar if the sequence is invalid.
+func (gp *grammar_parser) parse_sequence(rule *ebnf_rule, open *ebnf_node, after_bar bool) (*ebnf_node, error) {
+	closer := '.'
+
+	if open != nil {
+		closer = open.closer()
+	}
+
+	seq := &ebnf_node{
+		kind: ebnf_sequence,
+	}
+
+	// end_line and end_column are the position right after the last term.
+	end_line, end_column := gp.line, gp.column
+
+	for {
+		gp.skip_spaces()
+
+		seq.sym.line, seq.sym.column = gp.line, gp.column
+
+		c, ok := gp.peek()
+
+		if !ok || gp.is_rule_start() || (open != nil && c == '.') {
+			if open != nil {
+				return nil, NewErrGrammar(open.sym.line, open.sym.column, fmt.Errorf("missing %q to close %q in rule %q",
+					string(closer), string(open.opener()), rule.lhs.name))
+			} else if len(seq.children) == 0 {
+				return nil, seq.empty_error(rule, !after_bar)
+			}
+
+			return nil, NewErrGrammar(end_line, end_column, fmt.Errorf("missing %q at the end of rule %q", ".", rule.lhs.name))
+		}
+
+		if c == closer || c == '|' {
+			break
+		}
+
+		term, err := gp.parse_term(rule, closer)
+		if err != nil {
+			return nil, err
+		}
+
+		seq.children = append(seq.children, term)
+
+		end_line, end_column = gp.line, gp.column
+	}
+
+	if len(seq.children) == 0 {
+		return nil, seq.empty_error(rule, open == nil && !after_bar)
+	}
+
+	return seq, nil
+}
+
+// parse_term is a helper function that parses a single term; that is, an identifier or a
+// group between "{" and "}" or between "[" and "]".
+//
+// Parameters:
+//   - rule: The rule being parsed.
+//   - closer: The character that closes the enclosing element.
+//
+// Returns:
+//   - *ebnf_node: The term.
+//   - error: An error of type *ErrGrammar if the term is invalid.
+func (gp *grammar_parser) parse_term(rule *ebnf_rule, closer rune) (*ebnf_node, error) {
+	c, _ := gp.peek()
+
+	var kind ebnf_kind
+
+	switch c {
+	case '{':
+		kind = ebnf_repetition
+	case '[':
+		kind = ebnf_option
+	default:
+		sym, ok := gp.lex_identifier()
+		if !ok {
+			return nil, gp.unexpected("a right hand side or " + strconv.Quote(string(closer)))
+		}
+
+		return &ebnf_node{
+			kind: ebnf_symbol,
+			sym:  sym,
+		}, nil
+	}
+
+	group := &ebnf_node{
+		kind: kind,
+		sym: raw_symbol{
+			line:   gp.line,
+			column: gp.column,
+		},
+	}
+
+	gp.next() // consume the opening character
+
+	body, err := gp.parse_alternatives(rule, group)
+	if err != nil {
+		return nil, err
+	}
+
+	gp.next() // consume the closing character
+
+	group.children = []*ebnf_node{body}
+
+	return group, nil
+}
+
+// unexpected is a helper function that creates an error for an unexpected character at
+// the current position.
+//
+// Parameters:
+//   - expected: The description of what was expected.
+//
+// Returns:
+//   - *ErrGrammar: The error. Never returns nil.
+func (gp *grammar_parser) unexpected(expected string) *ErrGrammar {
+	c, ok := gp.peek()
+	if !ok {
+		return NewErrGrammar(gp.line, gp.column, fmt.Errorf("expected %s, got nothing instead", expected))
+	}
+
+	return NewErrGrammar(gp.line, gp.column, fmt.Errorf("expected %s, got %q instead", expected, c))
+}
+
+// parse is a helper function that parses all the rules of the grammar.
+//
+// Returns:
+//   - []*ebnf_rule: The rules; in the order they appear in the grammar.
+//   - error: An error of type *ErrGrammar if the grammar is invalid.
+func (gp *grammar_parser) parse() ([]*ebnf_rule, error) {
+	var rules []*ebnf_rule
+
+	for {
+		gp.skip_spaces()
+
+		_, ok := gp.peek()
+		if !ok {
+			break
+		}
+
+		rule, err := gp.parse_rule()
+		if err != nil {
+			return nil, err
+		}
+
+		rules = append(rules, rule)
+	}
+
+	if len(rules) == 0 {
+		return nil, NewErrGrammar(gp.line, gp.column, errors.New("no rules found"))
+	}
+
+	return rules, nil
+}
+
+// resolve_rules is a helper function that resolves the symbols of the rules.
+//
+// Parameters:
+//   - raws: The rules to resolve.
+//   - f: The function that transforms a field into a token type.
+//
+// Returns:
+//   - []*Rule: The resolved rules.
+//   - error: An error of type *ErrGrammar if a symbol is unknown or a rule is duplicated.
+//
+// Assertions:
+//   - f must not be nil.
+func resolve_rules[T TokenTyper](raws []*raw_rule, f StringToTypeFunc[T]) ([]*Rule[T], error) {
+	uc.AssertParam("f", f != nil, errors.New("value must not be nil"))
+
+	resolve := func(sym raw_symbol) (T, error) {
+		typ, ok := f(sym.name)
+		if !ok && sym.is_helper {
+			return typ, NewErrGrammar(sym.line, sym.column, fmt.Errorf("unknown helper symbol %q for the repetition", sym.name))
+		} else if !ok {
+			return typ, NewErrGrammar(sym.line, sym.column, fmt.Errorf("unknown symbol %q", sym.name))
+		}
+
+		return typ, nil
+	}
+
+	rules := make([]*Rule[T], 0, len(raws))
+
+	for _, raw := range raws {
+		lhs, err := resolve(raw.lhs)
+		if err != nil {
+			return nil, err
+		}
+
+		rhss := make([]T, 0, len(raw.rhss))
+
+		for _, sym := range raw.rhss {
+			rhs, err := resolve(sym)
+			if err != nil {
+				return nil, err
+			}
+
+			rhss = append(rhss, rhs)
+		}
+
+		slices.Reverse(rhss)
+
+		rule := NewRule(lhs, rhss)
+		uc.Assert(rule != nil, "rule must not be nil")
+
+		for i, other := range rules {
+			if other.Equals(rule) {
+				first := raws[i].lhs
+
+				return nil, NewErrGrammar(raw.lhs.line, raw.lhs.column, fmt.Errorf("duplicate rule %q; first defined at %d:%d",
+					raw.String(), first.line, first.column))
+			}
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+// parse_grammar is a helper function that parses the rules of a grammar.
+//
+// Parameters:
+//   - grammar: The grammar string.
+//   - f: The function that transforms a field into a token type.
+//
+// Returns:
+//   - []*Rule: The rules.
+//   - []T: The helpers introduced by repetitions.
+//   - error: An error of type *ErrGrammar if the grammar is invalid.
+//
+// Assertions:
+//   - f must not be nil.
+func parse_grammar[T TokenTyper](grammar string, f StringToTypeFunc[T]) ([]*Rule[T], []T, error) {
+	uc.AssertParam("f", f != nil, errors.New("value must not be nil"))
+
+	gp, err := new_grammar_parser(grammar)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ebnf_rules, err := gp.parse()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	raws, helper_names, err := desugar(ebnf_rules)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rules, err := resolve_rules(raws, f)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	helpers := make([]T, 0, len(helper_names))
+
+	for _, name := range helper_names {
+		helper, ok := f(name)
+		uc.AssertOk(ok, "f(%q)", name)
+
+		pos, ok := slices.BinarySearch(helpers, helper)
+		if !ok {
+			helpers = slices.Insert(helpers, pos, helper)
+		}
+	}
+
+	return rules, helpers, nil
+}
+
+// ParseGrammar parses the rules of a grammar.
+//
+// Here's the EBNF of the grammar language:
+//
+//	Grammar = Rule { Rule } .
+//	Rule = identifier "=" Alternatives "." .
+//	Alternatives = Sequence { "|" Sequence } .
+//	Sequence = Term { Term } .
+//	Term = identifier | "{" Alternatives "}" | "[" Alternatives "]" .
+//	identifier = letter { letter | digit | "_" } .
+//
+// Whitespace and newlines are ignored between elements. Alternatives and optional groups
+// are expanded into several rules while each repetition is replaced by a helper
+// non-terminal named after the left hand side of its rule followed by a number. For
+// instance,
+//
+//	Source = Elem { Elem } EOF .
+//
+// is desugared into:
+//
+//	Source = Elem EOF .
+//	Source = Elem Source1 EOF .
+//	Source1 = Elem .
+//	Source1 = Source1 Elem .
+//
+// Thus, f must also know the names of the helpers.
+//
+// Parameters:
+//   - grammar: The grammar string.
+//   - f: The function that transforms a field into a token type.
+//
+// Returns:
+//   - []*Rule: The rules; in the order they appear in the grammar.
+//   - error: An error if the grammar is invalid.
+//
+// Errors:
+//   - *common.ErrInvalidParameter: If f is nil.
+//   - *ErrGrammar: If the grammar is invalid. Its position points to the offending element.
+func ParseGrammar[T TokenTyper](grammar string, f StringToTypeFunc[T]) ([]*Rule[T], error) {
+	if f == nil {
+		return nil, uc.NewErrNilParameter("f")
+	}
+
+	rules, _, err := parse_grammar(grammar, f)
+	if err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+// ParseGrammarNames parses a grammar without resolving its symbols. This is useful for
+// tools that generate the token type of a grammar.
+//
+// See ParseGrammar for the syntax of the grammar.
+//
+// Parameters:
+//   - grammar: The grammar string.
+//
+// Returns:
+//   - []string: The names of all the symbols of the grammar; including the helpers
+//     introduced by repetitions. The names are in the order they first appear in the
+//     desugared rules.
+//   - []string: The names of the helpers; in the order they were introduced.
+//   - error: An error of type *ErrGrammar if the grammar is invalid.
+func ParseGrammarNames(grammar string) ([]string, []string, error) {
+	gp, err := new_grammar_parser(grammar)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ebnf_rules, err := gp.parse()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	raws, helpers, err := desugar(ebnf_rules)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var names []string
+	seen := make(map[string]bool)
+
+	add := func(sym raw_symbol) {
+		if seen[sym.name] {
+			return
+		}
+
+		seen[sym.name] = true
+		names = append(names, sym.name)
+	}
+
+	for _, raw := range raws {
+		add(raw.lhs)
+
+		for _, rhs := range raw.rhss {
+			add(rhs)
+		}
+	}
+
+	return names, helpers, nil
+}
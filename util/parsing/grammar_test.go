@@ -0,0 +1,170 @@
+package parsing
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseGrammar(t *testing.T) {
+	rules, err := ParseGrammar(ExprGrammar, ExprSttFunc)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	expecteds := []string{
+		"source = expression end of file .",
+		"expression = expression plus number .",
+		"expression = number .",
+	}
+
+	if len(rules) != len(expecteds) {
+		t.Fatalf("expected %d rules, got %d", len(expecteds), len(rules))
+	}
+
+	for i, expected := range expecteds {
+		if rules[i].String() != expected {
+			t.Errorf("expected %q, got %q", expected, rules[i].String())
+		}
+	}
+}
+
+func TestParseGrammarErrors(t *testing.T) {
+	tests := []struct {
+		grammar string
+		line    int
+		column  int
+		message string
+	}{
+		{
+			grammar: "Source = Expr EOF .\nExpr = numb .",
+			line:    2,
+			column:  8,
+			message: "2:8: unknown symbol \"numb\"",
+		},
+		{
+			grammar: "Source = Expr EOF\nExpr = num .",
+			line:    1,
+			column:  18,
+			message: "1:18: missing \".\" at the end of rule \"Source\"",
+		},
+		{
+			grammar: "Source = Expr EOF .\nExpr = num",
+			line:    2,
+			column:  11,
+			message: "2:11: missing \".\" at the end of rule \"Expr\"",
+		},
+		{
+			grammar: "Source = Expr EOF .\nExpr = .",
+			line:    2,
+			column:  8,
+			message: "2:8: rule \"Expr\" has an empty right hand side",
+		},
+		{
+			grammar: "Source = Expr EOF .\nExpr = num .\n  Expr = num .",
+			line:    3,
+			column:  3,
+			message: "3:3: duplicate rule \"Expr = num .\"; first defined at 2:1",
+		},
+		{
+			grammar: "Source Expr EOF .",
+			line:    1,
+			column:  8,
+			message: "1:8: expected \"=\", got 'E' instead",
+		},
+	}
+
+	for _, test := range tests {
+		_, err := ParseGrammar(test.grammar, ExprSttFunc)
+		if err == nil {
+			t.Errorf("expected error for %q, got nil", test.grammar)
+			continue
+		}
+
+		var grammar_err *ErrGrammar
+
+		ok := errors.As(err, &grammar_err)
+		if !ok {
+			t.Errorf("expected *ErrGrammar, got %T instead", err)
+			continue
+		}
+
+		if grammar_err.Line != test.line || grammar_err.Column != test.column {
+			t.Errorf("expected %d:%d, got %d:%d", test.line, test.column, grammar_err.Line, grammar_err.Column)
+		}
+
+		if err.Error() != test.message {
+			t.Errorf("expected %q, got %q", test.message, err.Error())
+		}
+	}
+}
+
+func TestParseGrammarEBNF(t *testing.T) {
+	const grammar string = `
+Source = Expr EOF .
+Expr = num { plus num | plus [ plus ] Expr } .
+`
+
+	rules, err := ParseGrammar(grammar, ExprSttFunc)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	expecteds := []string{
+		"source = expression end of file .",
+		"expression = number .",
+		"expression = number expression (I) .",
+		"expression (I) = plus number .",
+		"expression (I) = plus plus expression .",
+		"expression (I) = plus expression .",
+		"expression (I) = expression (I) plus number .",
+		"expression (I) = expression (I) plus plus expression .",
+		"expression (I) = expression (I) plus expression .",
+	}
+
+	if len(rules) != len(expecteds) {
+		t.Fatalf("expected %d rules, got %d", len(expecteds), len(rules))
+	}
+
+	for i, expected := range expecteds {
+		if rules[i].String() != expected {
+			t.Errorf("expected %q, got %q", expected, rules[i].String())
+		}
+	}
+}
+
+func TestParseGrammarEBNFErrors(t *testing.T) {
+	tests := []struct {
+		grammar string
+		message string
+	}{
+		{
+			grammar: "Source = Expr EOF .\nExpr = num { plus num .",
+			message: "2:12: missing \"}\" to close \"{\" in rule \"Expr\"",
+		},
+		{
+			grammar: "Source = Expr EOF .\nExpr = num { [ plus ] } .",
+			message: "2:12: repetition in rule \"Expr\" may match nothing",
+		},
+		{
+			grammar: "Source = Expr EOF .\nExpr = [ num ] .",
+			message: "2:1: rule \"Expr\" may have an empty right hand side",
+		},
+		{
+			grammar: "Source = Expr EOF .\nExpr = num | .",
+			message: "2:14: empty alternative in rule \"Expr\"",
+		},
+		{
+			grammar: "Source = Expr { Expr } EOF .\nExpr = num .",
+			message: "1:15: unknown helper symbol \"Source1\" for the repetition",
+		},
+	}
+
+	for _, test := range tests {
+		_, err := ParseGrammar(test.grammar, ExprSttFunc)
+		if err == nil {
+			t.Errorf("expected error for %q, got nil", test.grammar)
+		} else if err.Error() != test.message {
+			t.Errorf("expected %q, got %q", test.message, err.Error())
+		}
+	}
+}
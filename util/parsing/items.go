@@ -1,12 +1,10 @@
 package parsing
 
 import (
-	"fmt"
 	"slices"
 	"strings"
 
 	uc "github.com/PlayerR9/lib_units/common"
-	utstr "github.com/PlayerR9/lib_units/strings"
 )
 
 // StringToTypeFunc is a function that transforms a string into a token type.
@@ -24,16 +22,24 @@ type Rule[T TokenTyper] struct {
 	// lhs is the left hand side.
 	lhs T
 
-	// rhss are the right hand sides.
+	// rhss are the right hand sides; stored in reverse order. So rhss[0] is the last
+	// symbol of the rule (the accept symbol, for the start rule) and rhss[len(rhss)-1] is
+	// the first one.
 	rhss []T
 }
 
 // String implements the fmt.Stringer interface.
+//
+// Format:
+//
+//	Lhs = Rhs1 Rhs2 ... .
+//
+// The right hand sides are written in the order they appear in the grammar.
 func (r *Rule[T]) String() string {
 	values := make([]string, 0, len(r.rhss))
 
-	for _, rhs := range r.rhss {
-		values = append(values, rhs.String())
+	for i := len(r.rhss) - 1; i >= 0; i-- {
+		values = append(values, r.rhss[i].String())
 	}
 
 	var builder strings.Builder
@@ -64,6 +70,21 @@ func (r *Rule[T]) Copy() uc.Copier {
 	}
 }
 
+// Equals checks whether two rules have the same left and right hand sides.
+//
+// Parameters:
+//   - other: The other rule.
+//
+// Returns:
+//   - bool: True if the rules are equal. False otherwise.
+func (r *Rule[T]) Equals(other *Rule[T]) bool {
+	if other == nil {
+		return false
+	}
+
+	return r.lhs == other.lhs && slices.Equal(r.rhss, other.rhss)
+}
+
 // NewRule creates a new rule.
 //
 // Parameters:
@@ -184,6 +205,10 @@ func (item *Item[T]) String() string {
 		i++
 	}
 
+	if item.pos == len(item.rule.rhss) {
+		values = append(values, "[", "]")
+	}
+
 	var act_str string
 
 	if item.action != nil {
@@ -207,11 +232,14 @@ func (item *Item[T]) String() string {
 // Returns:
 //   - *Item: The new item.
 //   - error: An error of type *common.ErrInvalidParameter if the position is invalid or the rule is nil.
+//
+// A position of len(rhss) means that none of the right hand sides have been seen yet; see
+// Rule for their order.
 func NewItem[T TokenTyper](rule *Rule[T], pos int, act Actioner[T]) (*Item[T], error) {
 	if rule == nil {
 		return nil, uc.NewErrNilParameter("rule")
-	} else if pos < 0 || pos >= len(rule.rhss) {
-		return nil, uc.NewErrInvalidParameter("pos", uc.NewErrOutOfBounds(pos, 0, len(rule.rhss)))
+	} else if pos < 0 || pos > len(rule.rhss) {
+		return nil, uc.NewErrInvalidParameter("pos", uc.NewErrOutOfBounds(pos, 0, len(rule.rhss)).WithUpperBound(true))
 	}
 
 	if act == nil {
@@ -246,7 +274,7 @@ func NewItem[T TokenTyper](rule *Rule[T], pos int, act Actioner[T]) (*Item[T], e
 //
 // Returns:
 //   - bool: True if the lookahead matches. False otherwise.
-//   - error: An error if the lookahead does not match the rule.
+//   - error: An error of type *ErrUnexpected if the lookahead does not match the rule.
 //
 // As a special case, if item does not need lookahead, nil is returned regardless of the lookahead.
 func (item *Item[T]) MatchLookahead(la *T) (bool, error) {
@@ -254,22 +282,14 @@ func (item *Item[T]) MatchLookahead(la *T) (bool, error) {
 		return false, nil
 	}
 
-	if la == nil {
-		return false, fmt.Errorf("expected %q, got nothing instead", item.lookaheads[0].String())
-	}
-
-	_, ok := slices.BinarySearch(item.lookaheads, *la)
-	if ok {
-		return true, nil
-	}
-
-	values := make([]string, 0, len(item.lookaheads))
-
-	for _, lookahead := range item.lookaheads {
-		values = append(values, lookahead.GoString())
+	if la != nil {
+		_, ok := slices.BinarySearch(item.lookaheads, *la)
+		if ok {
+			return true, nil
+		}
 	}
 
-	return false, fmt.Errorf("expected %s, got %q instead", utstr.OrString(values, true, false), *la)
+	return false, NewErrUnexpected(la, nil, item.GetLookaheads()...)
 }
 
 // GetLhs returns the left hand side.
@@ -330,3 +350,51 @@ func (item *Item[T]) GetRhsFromStart(start int) (T, bool) {
 
 	return item.rule.GetRhsFromStart(start)
 }
+
+// GetLookaheads returns the lookaheads of the item.
+//
+// Returns:
+//   - []T: The lookaheads. Nil if the item is only valid at the end of the input.
+func (item *Item[T]) GetLookaheads() []T {
+	if len(item.lookaheads) == 0 {
+		return nil
+	}
+
+	las := make([]T, len(item.lookaheads))
+	copy(las, item.lookaheads)
+
+	return las
+}
+
+// add_lookaheads is a helper function that adds the given lookaheads to the item; ignoring duplicates.
+//
+// Parameters:
+//   - las: The lookaheads to add.
+//
+// Returns:
+//   - bool: True if at least one lookahead was added. False otherwise.
+func (item *Item[T]) add_lookaheads(las []T) bool {
+	var changed bool
+
+	for _, la := range las {
+		pos, ok := slices.BinarySearch(item.lookaheads, la)
+		if !ok {
+			item.lookaheads = slices.Insert(item.lookaheads, pos, la)
+			changed = true
+		}
+	}
+
+	return changed
+}
+
+// is_same_core is a helper function that checks whether two items have the same rule and position;
+// regardless of their lookaheads.
+//
+// Parameters:
+//   - other: The other item.
+//
+// Returns:
+//   - bool: True if the items have the same core. False otherwise.
+func (item *Item[T]) is_same_core(other *Item[T]) bool {
+	return item.pos == other.pos && item.rule.Equals(other.rule)
+}
@@ -0,0 +1,600 @@
+package parsing
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	uc "github.com/PlayerR9/lib_units/common"
+)
+
+// Parser is an LR parser driven by a decision table. It can be used for any grammar.
+type Parser[T TokenTyper] struct {
+	// dt is the decision table.
+	dt *DecisionTable[T]
+
+	// tokens are the tokens that were not shifted yet. When the tokens come from source, it
+	// holds at most the lookahead.
+	tokens []*Token[T]
+
+	// source is where the tokens come from when parsing an iterator. Nil when parsing a
+	// slice or once the iterator is exhausted.
+	source uc.Iterater[*Token[T]]
+
+	// source_err is the error returned by source that was not reported yet. Nil if there
+	// is none.
+	source_err error
+
+	// resume is true if parsing resumes after source fails with a syntax error.
+	resume bool
+
+	// failed is true if the last call to source failed.
+	failed bool
+
+	// stack is the parser stack.
+	stack *Stack[T]
+
+	// pos is the index of the next token in the input.
+	pos int
+
+	// syncs are the terminals at which the parser resumes after a syntax error.
+	syncs []T
+}
+
+// NewParser creates a new parser.
+//
+// Parameters:
+//   - dt: The decision table of the grammar.
+//   - syncs: The synchronizing terminals used by ParseWithRecovery. (e.g., the token
+//     that closes a statement)
+//
+// Returns:
+//   - *Parser: The new parser.
+//   - error: An error of type *common.ErrNilParameter if dt is nil.
+func NewParser[T TokenTyper](dt *DecisionTable[T], syncs ...T) (*Parser[T], error) {
+	if dt == nil {
+		return nil, uc.NewErrNilParameter("dt")
+	}
+
+	return &Parser[T]{
+		dt:    dt,
+		syncs: syncs,
+	}, nil
+}
+
+// reset is a helper function that prepares the parser for a new input.
+//
+// Parameters:
+//   - tokens: The tokens to parse.
+//   - source: The iterator the tokens come from. Nil if tokens holds the whole input.
+func (p *Parser[T]) reset(tokens []*Token[T], source uc.Iterater[*Token[T]]) {
+	p.stack = NewStack[T]()
+	p.tokens = tokens
+	p.source = source
+	p.source_err = nil
+	p.resume = false
+	p.failed = false
+	p.pos = 0
+}
+
+// fill is a helper function that reads the next token from the source when there is no
+// lookahead.
+//
+// If the source fails, there is no next token until its error is reported. The source is
+// then only read again if parsing resumes after the error; that is, if the error is an
+// *ErrSyntax and the previous call did not fail.
+//
+// Returns:
+//   - bool: True if there is a next token, false otherwise.
+func (p *Parser[T]) fill() bool {
+	if len(p.tokens) > 0 {
+		return true
+	} else if p.source == nil || p.source_err != nil {
+		return false
+	}
+
+	tk, err := p.source.Consume()
+	if err != nil {
+		var exhausted *uc.ErrExhaustedIter
+
+		if errors.As(err, &exhausted) {
+			p.source = nil
+
+			return false
+		}
+
+		p.source_err = err
+
+		var syntax *ErrSyntax
+
+		if !p.resume || p.failed || !errors.As(err, &syntax) {
+			p.source = nil
+		}
+
+		p.failed = true
+
+		return false
+	}
+
+	p.failed = false
+	p.tokens = append(p.tokens, tk)
+
+	return true
+}
+
+// lookahead is a helper function that returns the type of the next token.
+//
+// Returns:
+//   - *T: The type of the next token. Nil if there are no more tokens.
+func (p *Parser[T]) lookahead() *T {
+	if !p.fill() {
+		return nil
+	}
+
+	return &p.tokens[0].Type
+}
+
+// position is a helper function that returns the position of the next token.
+//
+// Returns:
+//   - Position: The start of the next token; or the end of the last token if there are no
+//     more tokens.
+func (p *Parser[T]) position() Position {
+	if p.fill() {
+		return p.tokens[0].Start
+	}
+
+	if p.pos == 0 {
+		return Position{}
+	}
+
+	top, ok := p.stack.Peek()
+	if !ok {
+		return Position{}
+	}
+
+	return top.End
+}
+
+// error_at is a helper function that creates a syntax error at the next token.
+//
+// Parameters:
+//   - reason: The reason of the error.
+//
+// Returns:
+//   - *ErrSyntax: The error. Never returns nil.
+//
+// If the source of the tokens failed, its error is returned instead since it is the cause
+// of the syntax error.
+func (p *Parser[T]) error_at(reason error) *ErrSyntax {
+	if p.source_err == nil {
+		return NewErrSyntax(p.pos, p.position(), reason)
+	}
+
+	var err *ErrSyntax
+
+	if errors.As(p.source_err, &err) {
+		return err
+	}
+
+	return NewErrSyntax(p.pos, p.position(), p.source_err)
+}
+
+// skip is a helper function that discards the next token.
+func (p *Parser[T]) skip() {
+	p.tokens = p.tokens[1:]
+	p.pos++
+}
+
+// shift is a helper function that shifts the next token onto the stack.
+//
+// Returns:
+//   - bool: True if a token was shifted, false if there are no more tokens.
+func (p *Parser[T]) shift() bool {
+	if !p.fill() {
+		return false
+	}
+
+	first := p.tokens[0]
+	p.skip()
+
+	p.stack.Push(first)
+
+	return true
+}
+
+// reduce is a helper function that replaces the top of the stack with the left hand side
+// of the rule of the given action.
+//
+// Parameters:
+//   - act: The action to reduce with.
+//
+// Returns:
+//   - error: An error of type *ErrReduce if the stack does not match the rule.
+//
+// Assertions:
+//   - The action must not be nil.
+//   - The iterator of act must not be nil.
+func (p *Parser[T]) reduce(act Actioner[T]) error {
+	uc.AssertParam("act", act != nil, errors.New("act is nil"))
+
+	lhs := act.GetLHS()
+
+	iter := act.Iterator()
+	uc.Assert(iter != nil, "iterator should not be nil")
+
+	var prev *T
+
+	for {
+		curr, err := iter.Consume()
+		if err != nil {
+			break
+		}
+
+		top, ok := p.stack.Pop()
+		if !ok {
+			p.stack.RefuseMany()
+
+			return NewErrReduce(lhs, curr, prev, nil)
+		} else if top.Type != curr {
+			p.stack.RefuseMany()
+
+			return NewErrReduce(lhs, curr, prev, &top.Type)
+		}
+
+		prev = &curr
+	}
+
+	popped := p.stack.GetPopped()
+	p.stack.Accept()
+
+	tk := NewToken(lhs, popped, popped[len(popped)-1].Lookahead)
+	tk.SetSpan(popped[0].Start, popped[len(popped)-1].End)
+
+	p.stack.Push(tk)
+
+	return nil
+}
+
+// apply_action is a helper function that applies the given action.
+//
+// Parameters:
+//   - act: The action to apply.
+//
+// Returns:
+//   - bool: True if the action is an accept action, false otherwise.
+//   - error: An error if the action was not applied successfully.
+//
+// Assertions:
+//   - The action must not be nil.
+func (p *Parser[T]) apply_action(act Actioner[T]) (bool, error) {
+	uc.AssertParam("act", act != nil, errors.New("act is nil"))
+
+	switch act := act.(type) {
+	case *ActAccept[T]:
+		err := p.reduce(act)
+		if err != nil {
+			return false, fmt.Errorf("accept failed: %w", err)
+		}
+
+		return true, nil
+	case *ActReduce[T]:
+		err := p.reduce(act)
+		if err != nil {
+			return false, fmt.Errorf("reduce failed: %w", err)
+		}
+	case *ActShift[T]:
+		ok := p.shift()
+		if !ok {
+			return false, errors.New("shift failed")
+		}
+	default:
+		return false, fmt.Errorf("unexpected action %T", act)
+	}
+
+	return false, nil
+}
+
+// Parse parses the given tokens.
+//
+// Parameters:
+//   - tokens: The tokens to parse. The last one is usually an accept symbol.
+//
+// Returns:
+//   - *Token: The root of the parse tree.
+//   - error: An error of type *ErrSyntax if the tokens do not match the grammar.
+//
+// The parser can be reused; each call starts from an empty stack.
+func (p *Parser[T]) Parse(tokens []*Token[T]) (*Token[T], error) {
+	if len(tokens) == 0 {
+		return nil, errors.New("no tokens")
+	}
+
+	p.reset(tokens, nil)
+
+	return p.parse()
+}
+
+// ParseIter is like Parse but it consumes the tokens lazily from the given iterator; so
+// that only the lookahead is buffered. (e.g., a streaming lexer)
+//
+// Parameters:
+//   - iter: The iterator over the tokens to parse.
+//
+// Returns:
+//   - *Token: The root of the parse tree.
+//   - error: An error of type *ErrSyntax if the tokens do not match the grammar or if the
+//     iterator failed.
+func (p *Parser[T]) ParseIter(iter uc.Iterater[*Token[T]]) (*Token[T], error) {
+	if iter == nil {
+		return nil, uc.NewErrNilParameter("iter")
+	}
+
+	p.reset(nil, iter)
+
+	return p.parse()
+}
+
+// parse is a helper function that runs the parser until the input is accepted or the
+// first syntax error.
+//
+// Returns:
+//   - *Token: The root of the parse tree.
+//   - error: An error of type *ErrSyntax if the input does not match the grammar.
+func (p *Parser[T]) parse() (*Token[T], error) {
+	for {
+		act, err := p.dt.Decide(p.stack, p.lookahead())
+		if err != nil {
+			return nil, p.error_at(err)
+		}
+
+		is_done, err := p.apply_action(act)
+		if err != nil {
+			return nil, p.error_at(err)
+		}
+
+		if is_done {
+			break
+		}
+	}
+
+	top, ok := p.stack.Pop()
+	uc.Assert(ok, "no top element on the stack")
+
+	if !p.stack.IsEmpty() {
+		return nil, errors.New("some elements are left on the stack")
+	}
+
+	if p.fill() {
+		return nil, p.error_at(errors.New("tokens are left after the accept symbol"))
+	}
+
+	return top, nil
+}
+
+// resync is a helper function that resumes parsing after a syntax error. It discards the
+// tokens up to and including the next synchronizing terminal and then the top of the stack
+// until the next token can be decided. Accept symbols are never discarded.
+//
+// If a synchronizing terminal was discarded, the stack is preferably cut back to the start
+// of the construct it closes; so that parsing does not resume in the middle of the broken
+// construct.
+//
+// Returns:
+//   - bool: True if the parser can resume, false otherwise.
+func (p *Parser[T]) resync() bool {
+	if len(p.syncs) == 0 {
+		return false
+	}
+
+	var closer *T
+
+	for p.fill() {
+		first := p.tokens[0]
+		if first.Type.IsAcceptSymbol() {
+			break
+		}
+
+		p.skip()
+
+		if slices.Contains(p.syncs, first.Type) {
+			closer = &first.Type
+			break
+		}
+	}
+
+	if p.source_err != nil {
+		// The error of the source is reported first.
+		return true
+	}
+
+	for {
+		k := p.resume_point(closer)
+		if k != -1 {
+			p.stack.truncate(k)
+
+			return true
+		}
+
+		la := p.lookahead()
+		if la == nil || (*la).IsAcceptSymbol() {
+			return false
+		}
+
+		p.skip()
+	}
+}
+
+// resume_point is a helper function that finds how many elements of the stack can be kept
+// so that the next token can be decided.
+//
+// Parameters:
+//   - closer: The synchronizing terminal that was discarded. Nil if there is none.
+//
+// Returns:
+//   - int: The number of elements to keep. -1 if the next token cannot be decided.
+func (p *Parser[T]) resume_point(closer *T) int {
+	la := p.lookahead()
+
+	// The elements above the first one that leads to no state cannot be kept.
+	valid, _ := p.dt.walk(p.stack)
+
+	fallback := -1
+
+	for k := valid; k >= 0; k-- {
+		state := p.stack.state_at(k)
+
+		_, err := p.dt.decide(state, top_type(p.stack, k), la)
+		if err != nil {
+			continue
+		}
+
+		if closer == nil || p.dt.can_close(state, *closer) {
+			return k
+		}
+
+		if fallback == -1 {
+			fallback = k
+		}
+	}
+
+	return fallback
+}
+
+// partial_tree is a helper function that returns the tree built so far when the input
+// could not be accepted.
+//
+// Returns:
+//   - *Token: A token of the start symbol whose children are the elements of the stack.
+//     Nil if the stack is empty.
+func (p *Parser[T]) partial_tree() *Token[T] {
+	if p.stack.IsEmpty() {
+		return nil
+	}
+
+	start, ok := p.dt.start()
+	uc.Assert(ok, "decision table has no start symbol")
+
+	children := slices.Clone(p.stack.elems)
+
+	tk := NewToken(start, children, nil)
+	tk.SetSpan(children[0].Start, children[len(children)-1].End)
+
+	return tk
+}
+
+// ParseWithRecovery parses the given tokens like Parse but, instead of stopping at the
+// first syntax error, it skips the input up to the next synchronizing terminal and keeps
+// parsing; so that all the syntax errors are reported at once.
+//
+// Parameters:
+//   - tokens: The tokens to parse.
+//
+// Returns:
+//   - *Token: The root of the parse tree. If there are errors, the tree only holds the
+//     parts of the input that were parsed successfully; and, if the input could not be
+//     accepted, its root is a token of the start symbol with the partial trees as
+//     children. Nil if nothing could be parsed.
+//   - []*ErrSyntax: The syntax errors; in the order they were found. Nil if there are none.
+//
+// Without synchronizing terminals, it stops at the first error.
+func (p *Parser[T]) ParseWithRecovery(tokens []*Token[T]) (*Token[T], []*ErrSyntax) {
+	if len(tokens) == 0 {
+		return nil, []*ErrSyntax{NewErrSyntax(0, Position{}, errors.New("no tokens"))}
+	}
+
+	p.reset(tokens, nil)
+
+	return p.parse_with_recovery()
+}
+
+// ParseIterWithRecovery is like ParseWithRecovery but it consumes the tokens lazily from
+// the given iterator. If the iterator fails with an *ErrSyntax, the error is reported and
+// parsing resumes at the next token like after any other syntax error; so the iterator
+// should resume at a synchronizing terminal. If it fails otherwise, or twice in a row,
+// parsing stops and its error is the last one.
+//
+// Parameters:
+//   - iter: The iterator over the tokens to parse.
+//
+// Returns:
+//   - *Token: The root of the parse tree. See ParseWithRecovery.
+//   - []*ErrSyntax: The syntax errors; in the order they were found. Nil if there are none.
+func (p *Parser[T]) ParseIterWithRecovery(iter uc.Iterater[*Token[T]]) (*Token[T], []*ErrSyntax) {
+	if iter == nil {
+		return nil, []*ErrSyntax{NewErrSyntax(0, Position{}, uc.NewErrNilParameter("iter"))}
+	}
+
+	p.reset(nil, iter)
+	p.resume = true
+
+	return p.parse_with_recovery()
+}
+
+// parse_with_recovery is a helper function that runs the parser until the input is
+// accepted; recovering from syntax errors.
+//
+// Returns:
+//   - *Token: The root of the parse tree. See ParseWithRecovery.
+//   - []*ErrSyntax: The syntax errors. Nil if there are none.
+func (p *Parser[T]) parse_with_recovery() (*Token[T], []*ErrSyntax) {
+	var errs []*ErrSyntax
+
+	for {
+		act, err := p.dt.Decide(p.stack, p.lookahead())
+		if err != nil {
+			if p.source_err != nil {
+				// The source failed; its error is the cause.
+				errs = append(errs, p.error_at(err))
+
+				if p.source == nil {
+					return p.partial_tree(), errs
+				}
+
+				p.source_err = nil
+			} else if len(errs) > 0 && errs[len(errs)-1].Index == p.pos {
+				// Recovery made no progress; discard the offending token.
+				la := p.lookahead()
+				if la == nil || (*la).IsAcceptSymbol() {
+					return p.partial_tree(), errs
+				}
+
+				p.skip()
+			} else {
+				errs = append(errs, p.error_at(err))
+			}
+
+			if !p.resync() {
+				return p.partial_tree(), errs
+			}
+
+			continue
+		}
+
+		is_done, err := p.apply_action(act)
+		if err != nil {
+			errs = append(errs, p.error_at(err))
+
+			return p.partial_tree(), errs
+		}
+
+		if is_done {
+			break
+		}
+	}
+
+	if p.stack.Size() > 1 {
+		errs = append(errs, p.error_at(errors.New("some elements are left on the stack")))
+
+		return p.partial_tree(), errs
+	}
+
+	top, ok := p.stack.Pop()
+	uc.Assert(ok, "no top element on the stack")
+
+	if p.fill() {
+		errs = append(errs, p.error_at(errors.New("tokens are left after the accept symbol")))
+	}
+
+	return top, errs
+}
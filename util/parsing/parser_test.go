@@ -0,0 +1,349 @@
+package parsing
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	uc "github.com/PlayerR9/lib_units/common"
+)
+
+func TestParser(t *testing.T) {
+	dt, err := NewDecisionTable(ExprGrammar, ExprSttFunc)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	p, err := NewParser(dt)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	root, err := p.Parse(make_expr_tokens(EttNum, EttPlus, EttNum, EttEOF))
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	if root.Type != EttSource {
+		t.Fatalf("expected EttSource, got %s", root.Type.GoString())
+	}
+
+	children, ok := root.Data.([]*Token[ExprTokenType])
+	if !ok || len(children) != 2 {
+		t.Fatalf("expected 2 children, got %v", root.Data)
+	}
+
+	if children[0].Type != EttExpr || children[1].Type != EttEOF {
+		t.Errorf("expected [EttExpr, EttEOF], got [%s, %s]", children[0].Type.GoString(), children[1].Type.GoString())
+	}
+
+	// The parser is reusable.
+	_, err = p.Parse(make_expr_tokens(EttNum, EttEOF))
+	if err != nil {
+		t.Errorf("expected no error, got %s", err.Error())
+	}
+
+	_, err = p.Parse(make_expr_tokens(EttNum, EttPlus, EttEOF))
+	if err == nil {
+		t.Errorf("expected error, got nil")
+	}
+}
+
+func TestParseWithRecovery(t *testing.T) {
+	dt, err := NewDecisionTable(ExprGrammar, ExprSttFunc)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	p, err := NewParser(dt, EttPlus)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	tokens := make_expr_tokens(EttNum, EttPlus, EttPlus, EttNum, EttPlus, EttPlus, EttNum, EttEOF)
+
+	root, errs := p.ParseWithRecovery(tokens)
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 errors, got %v", errs)
+	}
+
+	if errs[0].Index != 2 || errs[1].Index != 5 {
+		t.Errorf("expected errors at tokens 2 and 5, got %d and %d", errs[0].Index, errs[1].Index)
+	}
+
+	expected := "at token 2: expected \"number\" after \"plus\", got \"plus\" instead"
+
+	if errs[0].Error() != expected {
+		t.Errorf("expected %q, got %q", expected, errs[0].Error())
+	}
+
+	if root == nil || root.Type != EttSource {
+		t.Fatalf("expected a EttSource root, got %v", root)
+	}
+}
+
+func TestParseWithRecoveryNoSync(t *testing.T) {
+	dt, err := NewDecisionTable(ExprGrammar, ExprSttFunc)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	p, err := NewParser(dt)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	root, errs := p.ParseWithRecovery(make_expr_tokens(EttNum, EttPlus, EttPlus, EttNum, EttEOF))
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 error, got %v", errs)
+	}
+
+	if root == nil || root.Type != EttSource {
+		t.Fatalf("expected a partial EttSource root, got %v", root)
+	}
+
+	children, ok := root.Data.([]*Token[ExprTokenType])
+	if !ok || len(children) != 2 || children[0].Type != EttExpr || children[1].Type != EttPlus {
+		t.Errorf("expected partial children [EttExpr, EttPlus], got %v", root.Data)
+	}
+}
+
+func TestParserSpans(t *testing.T) {
+	dt, err := NewDecisionTable(ExprGrammar, ExprSttFunc)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	p, err := NewParser(dt)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	// "1 + 2" on line 3; EOF right after it.
+	tokens := make_expr_tokens(EttNum, EttPlus, EttNum, EttEOF)
+
+	for i, tk := range tokens {
+		start := Position{Offset: 10 + 2*i, Line: 3, Column: 1 + 2*i}
+		end := Position{Offset: 11 + 2*i, Line: 3, Column: 2 + 2*i}
+
+		tk.SetSpan(start, end)
+	}
+
+	tokens[3].SetSpan(tokens[2].End, tokens[2].End)
+
+	root, err := p.Parse(tokens)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	children := root.Data.([]*Token[ExprTokenType])
+
+	expr := children[0]
+
+	if expr.Start != tokens[0].Start || expr.End != tokens[2].End {
+		t.Errorf("expected expression span %s-%s, got %s-%s", tokens[0].Start, tokens[2].End, expr.Start, expr.End)
+	}
+
+	if root.Start != tokens[0].Start || root.End != tokens[2].End {
+		t.Errorf("expected source span %s-%s, got %s-%s", tokens[0].Start, tokens[2].End, root.Start, root.End)
+	}
+
+	tokens = make_expr_tokens(EttNum, EttPlus, EttPlus, EttEOF)
+	tokens[2].SetSpan(Position{Offset: 4, Line: 1, Column: 5}, Position{Offset: 5, Line: 1, Column: 6})
+
+	_, err = p.Parse(tokens)
+	if err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+
+	SetSource(err, "expr.txt", "1 +\t+ 2")
+
+	expected := "expr.txt:1:5: expected \"number\" after \"plus\", got \"plus\" instead\n\t1 +\t+ 2\n\t   \t^"
+
+	if err.Error() != expected {
+		t.Errorf("expected %q, got %q", expected, err.Error())
+	}
+}
+
+func TestParseIter(t *testing.T) {
+	dt, err := NewDecisionTable(ExprGrammar, ExprSttFunc)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	p, err := NewParser(dt)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	tokens := make_expr_tokens(EttNum, EttPlus, EttNum, EttEOF)
+
+	root, err := p.ParseIter(uc.NewSimpleIterator(tokens))
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	if root.Type != EttSource {
+		t.Errorf("expected EttSource, got %s", root.Type.GoString())
+	}
+
+	// An iterator that fails after the first token.
+	failing := &failing_iterator{
+		tokens: make_expr_tokens(EttNum),
+		err:    errors.New("read failed"),
+	}
+
+	_, err = p.ParseIter(failing)
+	if err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+
+	if !errors.Is(err, failing.err) {
+		t.Errorf("expected the error of the iterator, got %s", err.Error())
+	}
+}
+
+// failing_iterator is an iterator that returns an error once its tokens are consumed.
+type failing_iterator struct {
+	tokens []*Token[ExprTokenType]
+	err    error
+}
+
+func (fi *failing_iterator) Consume() (*Token[ExprTokenType], error) {
+	if len(fi.tokens) == 0 {
+		return nil, fi.err
+	}
+
+	tk := fi.tokens[0]
+	fi.tokens = fi.tokens[1:]
+
+	return tk, nil
+}
+
+func (fi *failing_iterator) Restart() {}
+
+func TestParseIterWithRecovery(t *testing.T) {
+	dt, err := NewDecisionTable(ExprGrammar, ExprSttFunc)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	p, err := NewParser(dt, EttPlus)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	// The iterator fails once before the second plus and then resumes.
+	bad := NewErrSyntax(2, Position{}, errors.New("bad token"))
+
+	iter := &resuming_iterator{
+		tokens: make_expr_tokens(EttNum, EttPlus, EttPlus, EttNum, EttEOF),
+		errs:   map[int][]error{2: {bad}},
+	}
+
+	root, errs := p.ParseIterWithRecovery(iter)
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 error, got %v", errs)
+	}
+
+	if errs[0] != bad {
+		t.Errorf("expected the error of the iterator, got %s", errs[0].Error())
+	}
+
+	if root == nil || root.Type != EttSource {
+		t.Fatalf("expected a EttSource root, got %v", root)
+	}
+
+	// An iterator that fails twice in a row stops the parser.
+	worse := NewErrSyntax(2, Position{}, errors.New("worse token"))
+
+	iter = &resuming_iterator{
+		tokens: make_expr_tokens(EttNum, EttPlus, EttPlus, EttNum, EttEOF),
+		errs:   map[int][]error{2: {bad, worse}},
+	}
+
+	_, errs = p.ParseIterWithRecovery(iter)
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 errors, got %v", errs)
+	}
+
+	if errs[0] != bad || errs[1] != worse {
+		t.Errorf("expected the errors of the iterator, got %v", errs)
+	}
+}
+
+// resuming_iterator is an iterator that returns errors before some of its tokens and then
+// delivers them.
+type resuming_iterator struct {
+	tokens []*Token[ExprTokenType]
+
+	// errs are the errors returned before the token at the given index; in order.
+	errs map[int][]error
+
+	pos int
+}
+
+func (ri *resuming_iterator) Consume() (*Token[ExprTokenType], error) {
+	if errs := ri.errs[ri.pos]; len(errs) > 0 {
+		ri.errs[ri.pos] = errs[1:]
+
+		return nil, errs[0]
+	}
+
+	if ri.pos == len(ri.tokens) {
+		return nil, uc.NewErrExhaustedIter()
+	}
+
+	tk := ri.tokens[ri.pos]
+	ri.pos++
+
+	return tk, nil
+}
+
+func (ri *resuming_iterator) Restart() {}
+
+func TestParseWithRecoveryLeftovers(t *testing.T) {
+	// A malformed table that accepts "num EOF" with any number of numbers before it.
+	dt, err := NewStaticDecisionTable(&StaticTable[ExprTokenType]{
+		Rules: []StaticRule[ExprTokenType]{
+			{Lhs: EttSource, Rhss: []ExprTokenType{EttNum, EttEOF}},
+		},
+		States: []StaticState[ExprTokenType]{
+			{
+				Gotos:   map[ExprTokenType]int{EttNum: 1},
+				Actions: map[ExprTokenType]StaticAction{EttNum: {Kind: StaticShift}},
+			},
+			{
+				Gotos:   map[ExprTokenType]int{EttNum: 1, EttEOF: 2},
+				Actions: map[ExprTokenType]StaticAction{EttNum: {Kind: StaticShift}, EttEOF: {Kind: StaticShift}},
+			},
+			{
+				End: &StaticAction{Kind: StaticAccept, Rule: 0},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	p, err := NewParser(dt)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	_, errs := p.ParseWithRecovery(make_expr_tokens(EttNum, EttNum, EttEOF))
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 error, got %v", errs)
+	}
+
+	expected := "some elements are left on the stack"
+
+	if !strings.Contains(errs[0].Error(), expected) {
+		t.Errorf("expected %q, got %q", expected, errs[0].Error())
+	}
+
+	_, err = p.Parse(make_expr_tokens(EttNum, EttNum, EttEOF))
+	if err == nil || !strings.Contains(err.Error(), expected) {
+		t.Errorf("expected %q, got %v", expected, err)
+	}
+}
@@ -7,6 +7,11 @@ import (
 type Stack[T TokenTyper] struct {
 	elems  []*Token[T]
 	popped []*Token[T]
+
+	// states are the states of the decision table reached after the elements; states[i]
+	// after elems[:i+1]. It is computed lazily by the decision table and may be shorter
+	// than elems; never longer.
+	states []int
 }
 
 func NewStack[T TokenTyper]() *Stack[T] {
@@ -38,13 +43,41 @@ func (s *Stack[T]) Pop() (*Token[T], bool) {
 	}
 
 	tok := s.elems[len(s.elems)-1]
-	s.elems = s.elems[:len(s.elems)-1]
+	s.truncate(len(s.elems) - 1)
 
 	s.popped = append(s.popped, tok)
 
 	return tok, true
 }
 
+// truncate is a helper function that keeps the first n elements of the stack only.
+//
+// Parameters:
+//   - n: The number of elements to keep. It is assumed to be at most the size of the stack.
+func (s *Stack[T]) truncate(n int) {
+	s.elems = s.elems[:n]
+
+	if len(s.states) > n {
+		s.states = s.states[:n]
+	}
+}
+
+// state_at is a helper function that returns the state reached after the first n
+// elements of the stack.
+//
+// Parameters:
+//   - n: The number of elements. It is assumed that their states were computed.
+//
+// Returns:
+//   - int: The state. 0, the initial state, if n is 0.
+func (s *Stack[T]) state_at(n int) int {
+	if n == 0 {
+		return 0
+	}
+
+	return s.states[n-1]
+}
+
 func (s *Stack[T]) RefuseOne() bool {
 	if len(s.popped) == 0 {
 		return false
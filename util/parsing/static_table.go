@@ -0,0 +1,260 @@
+package parsing
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	uc "github.com/PlayerR9/lib_units/common"
+)
+
+// StaticActionKind is the kind of an action of a static table.
+type StaticActionKind int
+
+const (
+	// StaticShift is a shift action.
+	StaticShift StaticActionKind = iota
+
+	// StaticReduce is a reduce action.
+	StaticReduce
+
+	// StaticAccept is an accept action.
+	StaticAccept
+)
+
+// StaticAction is an action of a static table.
+type StaticAction struct {
+	// Kind is the kind of the action.
+	Kind StaticActionKind
+
+	// Rule is the index of the rule to reduce or accept. Ignored for shift actions.
+	Rule int
+}
+
+// StaticRule is a rule of a static table.
+type StaticRule[T TokenTyper] struct {
+	// Lhs is the left hand side.
+	Lhs T
+
+	// Rhss are the right hand sides; in the order they are written in the grammar.
+	Rhss []T
+}
+
+// StaticState is a state of a static table.
+type StaticState[T TokenTyper] struct {
+	// Gotos are the transitions of the state for both terminals and non-terminals.
+	Gotos map[T]int
+
+	// Actions are the actions of the state for each lookahead.
+	Actions map[T]StaticAction
+
+	// End is the action of the state when there is no lookahead. Nil if there is none.
+	End *StaticAction
+}
+
+// StaticTable is a decision table that was computed ahead of time. It is meant to be
+// written as Go source by a generator so that the LR(1) automaton does not have to be
+// built when the program starts.
+type StaticTable[T TokenTyper] struct {
+	// Rules are the rules of the grammar.
+	Rules []StaticRule[T]
+
+	// Helpers are the non-terminals introduced by repetitions.
+	Helpers []T
+
+	// States are the states of the automaton. The first state is the initial state.
+	States []StaticState[T]
+}
+
+// Static returns the static table of the decision table.
+//
+// Returns:
+//   - *StaticTable: The static table. Never returns nil.
+func (dt *DecisionTable[T]) Static() *StaticTable[T] {
+	table := &StaticTable[T]{
+		Rules:   make([]StaticRule[T], 0, len(dt.rules)),
+		Helpers: slices.Clone(dt.helpers),
+		States:  make([]StaticState[T], 0, len(dt.states)),
+	}
+
+	for _, rule := range dt.rules {
+		rhss := slices.Clone(rule.rhss)
+
+		slices.Reverse(rhss)
+
+		table.Rules = append(table.Rules, StaticRule[T]{
+			Lhs:  rule.lhs,
+			Rhss: rhss,
+		})
+	}
+
+	for _, is := range dt.states {
+		state := StaticState[T]{
+			Gotos:   make(map[T]int, len(is.gotos)),
+			Actions: make(map[T]StaticAction, len(is.actions)),
+		}
+
+		for symbol, next := range is.gotos {
+			state.Gotos[symbol] = next
+		}
+
+		for la, act := range is.actions {
+			state.Actions[la] = dt.static_action(act)
+		}
+
+		if is.end != nil {
+			end := dt.static_action(is.end)
+			state.End = &end
+		}
+
+		table.States = append(table.States, state)
+	}
+
+	return table
+}
+
+// static_action is a helper function that converts an action into a static action.
+//
+// Parameters:
+//   - act: The action.
+//
+// Returns:
+//   - StaticAction: The static action.
+//
+// Assertions:
+//   - The rule of a reduce or accept action is one of the rules of the table.
+func (dt *DecisionTable[T]) static_action(act Actioner[T]) StaticAction {
+	var kind StaticActionKind
+	var rule *Rule[T]
+
+	switch act := act.(type) {
+	case *ActShift[T]:
+		return StaticAction{Kind: StaticShift}
+	case *ActReduce[T]:
+		kind = StaticReduce
+		rule = act.rule
+	case *ActAccept[T]:
+		kind = StaticAccept
+		rule = act.rule
+	default:
+		uc.AssertParam("act", false, fmt.Errorf("unknown action type %T", act))
+	}
+
+	idx := slices.IndexFunc(dt.rules, rule.Equals)
+	uc.AssertParam("act", idx != -1, errors.New("rule of the action is not in the table"))
+
+	return StaticAction{Kind: kind, Rule: idx}
+}
+
+// to_actioner is a helper function that converts a static action into an action.
+//
+// Parameters:
+//   - sa: The static action.
+//   - rules: The rules of the table.
+//
+// Returns:
+//   - Actioner: The action.
+//   - error: An error if the kind is unknown or if the rule is out of bounds.
+func to_actioner[T TokenTyper](sa StaticAction, rules []*Rule[T]) (Actioner[T], error) {
+	if sa.Kind == StaticShift {
+		return NewActShift[T](), nil
+	}
+
+	if sa.Rule < 0 || sa.Rule >= len(rules) {
+		return nil, fmt.Errorf("rule %w", uc.NewErrOutOfBounds(sa.Rule, 0, len(rules)))
+	}
+
+	switch sa.Kind {
+	case StaticReduce:
+		return NewActReduce(rules[sa.Rule]), nil
+	case StaticAccept:
+		return NewActAccept(rules[sa.Rule]), nil
+	default:
+		return nil, fmt.Errorf("unknown action kind %d", sa.Kind)
+	}
+}
+
+// NewStaticDecisionTable creates a decision table from a static table. Unlike
+// NewDecisionTable, the grammar is neither parsed nor checked; only the indices of the
+// table are.
+//
+// Parameters:
+//   - table: The static table.
+//
+// Returns:
+//   - *DecisionTable: The new decision table.
+//   - error: An error if the table is invalid.
+//
+// Errors:
+//   - *common.ErrNilParameter: If the table is nil.
+//   - *common.ErrInvalidParameter: If the table has no rules or no states.
+//   - *common.ErrAt: If a rule or a state is invalid.
+func NewStaticDecisionTable[T TokenTyper](table *StaticTable[T]) (*DecisionTable[T], error) {
+	if table == nil {
+		return nil, uc.NewErrNilParameter("table")
+	} else if len(table.Rules) == 0 {
+		return nil, uc.NewErrInvalidParameter("table.Rules", uc.NewErrEmpty(table.Rules))
+	} else if len(table.States) == 0 {
+		return nil, uc.NewErrInvalidParameter("table.States", uc.NewErrEmpty(table.States))
+	}
+
+	dt := &DecisionTable[T]{
+		rules:  make([]*Rule[T], 0, len(table.Rules)),
+		states: make([]*item_set[T], 0, len(table.States)),
+	}
+
+	for i, sr := range table.Rules {
+		if len(sr.Rhss) == 0 {
+			return nil, uc.NewErrAt(i+1, "rule", errors.New("right hand side must not be empty"))
+		}
+
+		rhss := slices.Clone(sr.Rhss)
+
+		slices.Reverse(rhss)
+
+		dt.rules = append(dt.rules, NewRule(sr.Lhs, rhss))
+	}
+
+	dt.helpers = slices.Clone(table.Helpers)
+	slices.Sort(dt.helpers)
+
+	dt.make_symbols()
+
+	for i, ss := range table.States {
+		is := &item_set[T]{
+			gotos:   make(map[T]int, len(ss.Gotos)),
+			actions: make(map[T]Actioner[T], len(ss.Actions)),
+		}
+
+		for symbol, next := range ss.Gotos {
+			if next < 0 || next >= len(table.States) {
+				return nil, uc.NewErrAt(i+1, "state", fmt.Errorf("goto on %q: %w", symbol.String(),
+					uc.NewErrOutOfBounds(next, 0, len(table.States))))
+			}
+
+			is.gotos[symbol] = next
+		}
+
+		for la, sa := range ss.Actions {
+			act, err := to_actioner(sa, dt.rules)
+			if err != nil {
+				return nil, uc.NewErrAt(i+1, "state", fmt.Errorf("action on %q: %w", la.String(), err))
+			}
+
+			is.actions[la] = act
+		}
+
+		if ss.End != nil {
+			act, err := to_actioner(*ss.End, dt.rules)
+			if err != nil {
+				return nil, uc.NewErrAt(i+1, "state", fmt.Errorf("action at the end of the input: %w", err))
+			}
+
+			is.end = act
+		}
+
+		dt.states = append(dt.states, is)
+	}
+
+	return dt, nil
+}
@@ -0,0 +1,69 @@
+package parsing
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestStaticDecisionTable(t *testing.T) {
+	dt, err := NewDecisionTable(ExprGrammar, ExprSttFunc)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	static := dt.Static()
+
+	if len(static.Rules) != 3 || !slices.Equal(static.Rules[1].Rhss, []ExprTokenType{EttExpr, EttPlus, EttNum}) {
+		t.Fatalf("unexpected rules %v", static.Rules)
+	}
+
+	loaded, err := NewStaticDecisionTable(static)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	expecteds, err := run_decisions(dt, make_expr_tokens(EttNum, EttPlus, EttNum, EttEOF))
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	actions, err := run_decisions(loaded, make_expr_tokens(EttNum, EttPlus, EttNum, EttEOF))
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+
+	if !slices.Equal(actions, expecteds) {
+		t.Errorf("expected %v, got %v", expecteds, actions)
+	}
+
+	_, err = run_decisions(loaded, make_expr_tokens(EttNum, EttPlus, EttPlus, EttEOF))
+	if err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+
+	expected := "expected \"number\" after \"plus\", got \"plus\" instead"
+
+	if err.Error() != expected {
+		t.Errorf("expected %q, got %q", expected, err.Error())
+	}
+}
+
+func TestNewStaticDecisionTableInvalid(t *testing.T) {
+	table := &StaticTable[ExprTokenType]{
+		Rules: []StaticRule[ExprTokenType]{
+			{Lhs: EttSource, Rhss: []ExprTokenType{EttNum, EttEOF}},
+		},
+		States: []StaticState[ExprTokenType]{
+			{
+				Actions: map[ExprTokenType]StaticAction{
+					EttNum: {Kind: StaticReduce, Rule: 1},
+				},
+			},
+		},
+	}
+
+	_, err := NewStaticDecisionTable(table)
+	if err == nil {
+		t.Errorf("expected error, got nil")
+	}
+}
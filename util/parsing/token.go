@@ -28,10 +28,55 @@ type TokenTyper interface {
 	fmt.Stringer
 }
 
+// Position is a position in the source.
+type Position struct {
+	// Offset is the byte offset. (0-indexed)
+	Offset int
+
+	// Line is the line. (1-indexed) 0 if the position is unknown.
+	Line int
+
+	// Column is the column in runes. (1-indexed)
+	Column int
+}
+
+// String implements the fmt.Stringer interface.
+//
+// Format:
+//
+//	"{{ .Line }}:{{ .Column }}"
+func (p Position) String() string {
+	return strconv.Itoa(p.Line) + ":" + strconv.Itoa(p.Column)
+}
+
+// IsValid checks whether the position is known.
+//
+// Returns:
+//   - bool: True if the position is known. False otherwise.
+func (p Position) IsValid() bool {
+	return p.Line > 0
+}
+
 type Token[T TokenTyper] struct {
 	Type      T
 	Data      any // either string or []*Token[T]
 	Lookahead *Token[T]
+
+	// Start is the position of the first character of the token.
+	Start Position
+
+	// End is the position right after the last character of the token.
+	End Position
+}
+
+// SetSpan sets the positions of the token.
+//
+// Parameters:
+//   - start: The position of the first character of the token.
+//   - end: The position right after the last character of the token.
+func (t *Token[T]) SetSpan(start, end Position) {
+	t.Start = start
+	t.End = end
 }
 
 func (t *Token[T]) GoString() string {